@@ -0,0 +1,66 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package config
+
+import (
+	"fmt"
+	"os"
+
+	validator "github.com/go-playground/validator/v10"
+)
+
+type AdminConfig struct {
+	Token string `yaml:"token" env:"ADMIN_TOKEN" validate:"omitempty,min=32"`
+}
+
+func DefaultAdminConfig() *AdminConfig {
+	return &AdminConfig{}
+}
+
+func (c *AdminConfig) Enabled() bool {
+	return c.Token != ""
+}
+
+func (c *AdminConfig) loadEnv() error {
+	if token := os.Getenv("ADMIN_TOKEN"); token != "" {
+		c.Token = token
+	}
+
+	return nil
+}
+
+func (c *AdminConfig) Validate() error {
+	validate := validator.New()
+
+	if err := validate.Struct(c); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, e := range validationErrors {
+				switch e.Field() {
+				case "Token":
+					return fmt.Errorf("admin token must be at least 32 characters long")
+				default:
+					return fmt.Errorf("validation error on field %s: %s", e.Field(), e.Tag())
+				}
+			}
+		}
+
+		return err
+	}
+
+	return nil
+}
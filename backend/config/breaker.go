@@ -0,0 +1,107 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package config
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	validator "github.com/go-playground/validator/v10"
+)
+
+// CircuitBreakerConfig configures the circuit breakers and bulkheads guarding
+// each upstream host of the Miro, OAuth and Document Server clients.
+type CircuitBreakerConfig struct {
+	FailureThreshold int           `yaml:"failure_threshold" env:"CIRCUIT_BREAKER_FAILURE_THRESHOLD" validate:"gt=0"`
+	RecoveryTimeout  time.Duration `yaml:"recovery_timeout" env:"CIRCUIT_BREAKER_RECOVERY_TIMEOUT" validate:"gt=0"`
+	MaxConcurrent    int           `yaml:"max_concurrent" env:"CIRCUIT_BREAKER_MAX_CONCURRENT" validate:"gt=0"`
+	MaxWait          time.Duration `yaml:"max_wait" env:"CIRCUIT_BREAKER_MAX_WAIT" validate:"min=0"`
+}
+
+func DefaultCircuitBreakerConfig() *CircuitBreakerConfig {
+	return &CircuitBreakerConfig{
+		FailureThreshold: 5,
+		RecoveryTimeout:  30 * time.Second,
+		MaxConcurrent:    50,
+		MaxWait:          100 * time.Millisecond,
+	}
+}
+
+func (c *CircuitBreakerConfig) loadEnv() error {
+	if threshold := os.Getenv("CIRCUIT_BREAKER_FAILURE_THRESHOLD"); threshold != "" {
+		var thresholdInt int
+		if _, err := fmt.Sscanf(threshold, "%d", &thresholdInt); err != nil {
+			return fmt.Errorf("invalid failure threshold: %w", err)
+		}
+		c.FailureThreshold = thresholdInt
+	}
+
+	if timeout := os.Getenv("CIRCUIT_BREAKER_RECOVERY_TIMEOUT"); timeout != "" {
+		if duration, err := time.ParseDuration(timeout); err != nil {
+			return fmt.Errorf("invalid recovery timeout duration: %w", err)
+		} else {
+			c.RecoveryTimeout = duration
+		}
+	}
+
+	if concurrent := os.Getenv("CIRCUIT_BREAKER_MAX_CONCURRENT"); concurrent != "" {
+		var concurrentInt int
+		if _, err := fmt.Sscanf(concurrent, "%d", &concurrentInt); err != nil {
+			return fmt.Errorf("invalid max concurrent: %w", err)
+		}
+		c.MaxConcurrent = concurrentInt
+	}
+
+	if wait := os.Getenv("CIRCUIT_BREAKER_MAX_WAIT"); wait != "" {
+		if duration, err := time.ParseDuration(wait); err != nil {
+			return fmt.Errorf("invalid max wait duration: %w", err)
+		} else {
+			c.MaxWait = duration
+		}
+	}
+
+	return nil
+}
+
+func (c *CircuitBreakerConfig) Validate() error {
+	validate := validator.New()
+
+	if err := validate.Struct(c); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, e := range validationErrors {
+				switch e.Field() {
+				case "FailureThreshold":
+					return fmt.Errorf("failure_threshold must be positive")
+				case "RecoveryTimeout":
+					return fmt.Errorf("recovery_timeout must be positive")
+				case "MaxConcurrent":
+					return fmt.Errorf("max_concurrent must be positive")
+				case "MaxWait":
+					return fmt.Errorf("max_wait must be non-negative")
+				default:
+					return fmt.Errorf("validation error on field %s: %s", e.Field(), e.Tag())
+				}
+			}
+		}
+
+		return err
+	}
+
+	return nil
+}
@@ -25,15 +25,22 @@ import (
 )
 
 type Config struct {
-	Database   *DataSourceConfig `yaml:"database"`
-	Miro       *MiroConfig       `yaml:"miro"`
-	OAuth      *OAuthConfig      `yaml:"oauth"`
-	Server     *ServerConfig     `yaml:"server"`
-	Redis      *RedisConfig      `yaml:"redis"`
-	RateLimit  *RateLimitConfig  `yaml:"rate_limit"`
-	CORS       *CORSConfig       `yaml:"cors"`
-	DemoServer *DemoServerConfig `yaml:"demo_server"`
-	Logger     *LoggerConfig     `yaml:"logger"`
+	Database   *DataSourceConfig     `yaml:"database"`
+	Miro       *MiroConfig           `yaml:"miro"`
+	OAuth      *OAuthConfig          `yaml:"oauth"`
+	Server     *ServerConfig         `yaml:"server"`
+	Redis      *RedisConfig          `yaml:"redis"`
+	RateLimit  *RateLimitConfig      `yaml:"rate_limit"`
+	CORS       *CORSConfig           `yaml:"cors"`
+	DemoServer *DemoServerConfig     `yaml:"demo_server"`
+	Logger     *LoggerConfig         `yaml:"logger"`
+	Admin      *AdminConfig          `yaml:"admin"`
+	Encryption *EncryptionConfig     `yaml:"encryption"`
+	Storage    *StorageConfig        `yaml:"storage"`
+	Breaker    *CircuitBreakerConfig `yaml:"circuit_breaker"`
+	Metrics    *MetricsConfig        `yaml:"metrics"`
+	Tracing    *TracingConfig        `yaml:"tracing"`
+	Health     *HealthConfig         `yaml:"health"`
 }
 
 func DefaultConfig() *Config {
@@ -47,6 +54,13 @@ func DefaultConfig() *Config {
 		CORS:       DefaultCORSConfig(),
 		DemoServer: DefaultDemoServerConfig(),
 		Logger:     DefaultLoggerConfig(),
+		Admin:      DefaultAdminConfig(),
+		Encryption: DefaultEncryptionConfig(),
+		Storage:    DefaultStorageConfig(),
+		Breaker:    DefaultCircuitBreakerConfig(),
+		Metrics:    DefaultMetricsConfig(),
+		Tracing:    DefaultTracingConfig(),
+		Health:     DefaultHealthConfig(),
 	}
 }
 
@@ -97,12 +111,46 @@ func LoadConfig(path string) (*Config, error) {
 		return config, fmt.Errorf("failed to load logger environment variables: %w", err)
 	}
 
+	if err := config.Admin.loadEnv(); err != nil {
+		return config, fmt.Errorf("failed to load admin environment variables: %w", err)
+	}
+
+	if err := config.Encryption.loadEnv(); err != nil {
+		return config, fmt.Errorf("failed to load encryption environment variables: %w", err)
+	}
+
+	if err := config.Storage.loadEnv(); err != nil {
+		return config, fmt.Errorf("failed to load storage environment variables: %w", err)
+	}
+
+	if err := config.Breaker.loadEnv(); err != nil {
+		return config, fmt.Errorf("failed to load circuit breaker environment variables: %w", err)
+	}
+
+	if err := config.Metrics.loadEnv(); err != nil {
+		return config, fmt.Errorf("failed to load metrics environment variables: %w", err)
+	}
+
+	if err := config.Tracing.loadEnv(); err != nil {
+		return config, fmt.Errorf("failed to load tracing environment variables: %w", err)
+	}
+
+	if err := config.Health.loadEnv(); err != nil {
+		return config, fmt.Errorf("failed to load health environment variables: %w", err)
+	}
+
 	return config, nil
 }
 
 func (c *Config) Validate() error {
-	if err := c.Database.Validate(); err != nil {
-		return fmt.Errorf("invalid database config: %w", err)
+	if err := c.Storage.Validate(); err != nil {
+		return fmt.Errorf("invalid storage config: %w", err)
+	}
+
+	if !c.Storage.FileDatabase() {
+		if err := c.Database.Validate(); err != nil {
+			return fmt.Errorf("invalid database config: %w", err)
+		}
 	}
 
 	if err := c.Miro.Validate(); err != nil {
@@ -137,5 +185,29 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("invalid logger config: %w", err)
 	}
 
+	if err := c.Admin.Validate(); err != nil {
+		return fmt.Errorf("invalid admin config: %w", err)
+	}
+
+	if err := c.Encryption.Validate(); err != nil {
+		return fmt.Errorf("invalid encryption config: %w", err)
+	}
+
+	if err := c.Breaker.Validate(); err != nil {
+		return fmt.Errorf("invalid circuit breaker config: %w", err)
+	}
+
+	if err := c.Metrics.Validate(); err != nil {
+		return fmt.Errorf("invalid metrics config: %w", err)
+	}
+
+	if err := c.Tracing.Validate(); err != nil {
+		return fmt.Errorf("invalid tracing config: %w", err)
+	}
+
+	if err := c.Health.Validate(); err != nil {
+		return fmt.Errorf("invalid health config: %w", err)
+	}
+
 	return nil
 }
@@ -0,0 +1,128 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package config
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	validator "github.com/go-playground/validator/v10"
+)
+
+const (
+	EncryptionProviderStatic = "static"
+	EncryptionProviderFile   = "file"
+	EncryptionProviderVault  = "vault"
+)
+
+// EncryptionConfig selects how stored secrets are encrypted. The static
+// provider encrypts with the OAuth encryption secrets directly, while the
+// file and vault providers wrap per-value data keys with a key that never
+// leaves the key-management backend.
+type EncryptionConfig struct {
+	Provider     string        `yaml:"provider" env:"ENCRYPTION_PROVIDER" validate:"oneof=static file vault"`
+	KeyFile      string        `yaml:"key_file" env:"ENCRYPTION_KEY_FILE" validate:"required_if=Provider file"`
+	VaultAddress string        `yaml:"vault_address" env:"ENCRYPTION_VAULT_ADDRESS" validate:"required_if=Provider vault"`
+	VaultToken   string        `yaml:"vault_token" env:"ENCRYPTION_VAULT_TOKEN" validate:"required_if=Provider vault"`
+	VaultMount   string        `yaml:"vault_mount" env:"ENCRYPTION_VAULT_MOUNT" validate:"required"`
+	VaultKey     string        `yaml:"vault_key" env:"ENCRYPTION_VAULT_KEY" validate:"required_if=Provider vault"`
+	Timeout      time.Duration `yaml:"timeout" env:"ENCRYPTION_TIMEOUT" validate:"required"`
+}
+
+func DefaultEncryptionConfig() *EncryptionConfig {
+	return &EncryptionConfig{
+		Provider:   EncryptionProviderStatic,
+		VaultMount: "transit",
+		Timeout:    5 * time.Second,
+	}
+}
+
+// Envelope reports whether secrets are protected with wrapped data keys.
+func (c *EncryptionConfig) Envelope() bool {
+	return c.Provider != EncryptionProviderStatic
+}
+
+func (c *EncryptionConfig) loadEnv() error {
+	if provider := os.Getenv("ENCRYPTION_PROVIDER"); provider != "" {
+		c.Provider = provider
+	}
+
+	if keyFile := os.Getenv("ENCRYPTION_KEY_FILE"); keyFile != "" {
+		c.KeyFile = keyFile
+	}
+
+	if address := os.Getenv("ENCRYPTION_VAULT_ADDRESS"); address != "" {
+		c.VaultAddress = address
+	}
+
+	if token := os.Getenv("ENCRYPTION_VAULT_TOKEN"); token != "" {
+		c.VaultToken = token
+	}
+
+	if mount := os.Getenv("ENCRYPTION_VAULT_MOUNT"); mount != "" {
+		c.VaultMount = mount
+	}
+
+	if key := os.Getenv("ENCRYPTION_VAULT_KEY"); key != "" {
+		c.VaultKey = key
+	}
+
+	if timeout := os.Getenv("ENCRYPTION_TIMEOUT"); timeout != "" {
+		if duration, err := time.ParseDuration(timeout); err != nil {
+			return fmt.Errorf("invalid timeout duration: %w", err)
+		} else {
+			c.Timeout = duration
+		}
+	}
+
+	return nil
+}
+
+func (c *EncryptionConfig) Validate() error {
+	validate := validator.New()
+
+	if err := validate.Struct(c); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, e := range validationErrors {
+				switch e.Field() {
+				case "Provider":
+					return fmt.Errorf("provider must be one of static, file or vault")
+				case "KeyFile":
+					return fmt.Errorf("key_file is required for the file provider")
+				case "VaultAddress":
+					return fmt.Errorf("vault_address is required for the vault provider")
+				case "VaultToken":
+					return fmt.Errorf("vault_token is required for the vault provider")
+				case "VaultMount":
+					return fmt.Errorf("vault_mount is required")
+				case "VaultKey":
+					return fmt.Errorf("vault_key is required for the vault provider")
+				case "Timeout":
+					return fmt.Errorf("timeout is required")
+				default:
+					return fmt.Errorf("validation error on field %s: %s", e.Field(), e.Tag())
+				}
+			}
+		}
+
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package config
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	validator "github.com/go-playground/validator/v10"
+)
+
+// HealthConfig configures the readiness checks of external dependencies.
+type HealthConfig struct {
+	// CacheTTL is how long a readiness report is reused, so that frequent
+	// probes do not hammer the dependencies.
+	CacheTTL time.Duration `yaml:"cache_ttl" env:"HEALTH_CACHE_TTL" validate:"min=0"`
+	// Timeout bounds each individual check.
+	Timeout time.Duration `yaml:"timeout" env:"HEALTH_TIMEOUT" validate:"gt=0"`
+	// CheckDemoServer adds the demo Document Server to the readiness checks.
+	CheckDemoServer bool `yaml:"check_demo_server" env:"HEALTH_CHECK_DEMO_SERVER"`
+}
+
+func DefaultHealthConfig() *HealthConfig {
+	return &HealthConfig{
+		CacheTTL:        5 * time.Second,
+		Timeout:         2 * time.Second,
+		CheckDemoServer: false,
+	}
+}
+
+func (c *HealthConfig) loadEnv() error {
+	if ttl := os.Getenv("HEALTH_CACHE_TTL"); ttl != "" {
+		if duration, err := time.ParseDuration(ttl); err != nil {
+			return fmt.Errorf("invalid cache ttl duration: %w", err)
+		} else {
+			c.CacheTTL = duration
+		}
+	}
+
+	if timeout := os.Getenv("HEALTH_TIMEOUT"); timeout != "" {
+		if duration, err := time.ParseDuration(timeout); err != nil {
+			return fmt.Errorf("invalid timeout duration: %w", err)
+		} else {
+			c.Timeout = duration
+		}
+	}
+
+	if demo := os.Getenv("HEALTH_CHECK_DEMO_SERVER"); demo != "" {
+		c.CheckDemoServer = demo == "true"
+	}
+
+	return nil
+}
+
+func (c *HealthConfig) Validate() error {
+	validate := validator.New()
+
+	if err := validate.Struct(c); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, e := range validationErrors {
+				switch e.Field() {
+				case "CacheTTL":
+					return fmt.Errorf("cache_ttl must be non-negative")
+				case "Timeout":
+					return fmt.Errorf("timeout must be positive")
+				default:
+					return fmt.Errorf("validation error on field %s: %s", e.Field(), e.Tag())
+				}
+			}
+		}
+
+		return err
+	}
+
+	return nil
+}
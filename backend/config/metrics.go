@@ -0,0 +1,71 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package config
+
+import (
+	"fmt"
+	"os"
+
+	validator "github.com/go-playground/validator/v10"
+)
+
+// MetricsConfig configures the Prometheus metrics endpoint.
+type MetricsConfig struct {
+	Enabled bool   `yaml:"enabled" env:"METRICS_ENABLED"`
+	Path    string `yaml:"path" env:"METRICS_PATH" validate:"required,startswith=/"`
+}
+
+func DefaultMetricsConfig() *MetricsConfig {
+	return &MetricsConfig{
+		Enabled: true,
+		Path:    "/metrics",
+	}
+}
+
+func (c *MetricsConfig) loadEnv() error {
+	if enabled := os.Getenv("METRICS_ENABLED"); enabled != "" {
+		c.Enabled = enabled == "true"
+	}
+
+	if path := os.Getenv("METRICS_PATH"); path != "" {
+		c.Path = path
+	}
+
+	return nil
+}
+
+func (c *MetricsConfig) Validate() error {
+	validate := validator.New()
+
+	if err := validate.Struct(c); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, e := range validationErrors {
+				switch e.Field() {
+				case "Path":
+					return fmt.Errorf("metrics path must start with a slash")
+				default:
+					return fmt.Errorf("validation error on field %s: %s", e.Field(), e.Tag())
+				}
+			}
+		}
+
+		return err
+	}
+
+	return nil
+}
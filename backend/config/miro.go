@@ -25,15 +25,23 @@ import (
 	validator "github.com/go-playground/validator/v10"
 )
 
+// MiroConfig configures the Miro REST client. CreditReserve is the part of a
+// token's rate limit credits that bulk requests such as file listings leave
+// untouched for interactive ones, and MaxRateLimitWait bounds how long a
+// request waits for credits before failing as rate limited.
 type MiroConfig struct {
-	BaseURL string        `yaml:"base_url" env:"MIRO_BASE_URL" validate:"required"`
-	Timeout time.Duration `yaml:"timeout" env:"MIRO_TIMEOUT" validate:"required"`
+	BaseURL          string        `yaml:"base_url" env:"MIRO_BASE_URL" validate:"required"`
+	Timeout          time.Duration `yaml:"timeout" env:"MIRO_TIMEOUT" validate:"required"`
+	CreditReserve    int           `yaml:"credit_reserve" env:"MIRO_CREDIT_RESERVE" validate:"min=0"`
+	MaxRateLimitWait time.Duration `yaml:"max_rate_limit_wait" env:"MIRO_MAX_RATE_LIMIT_WAIT" validate:"min=0"`
 }
 
 func DefaultMiroConfig() *MiroConfig {
 	return &MiroConfig{
-		BaseURL: "https://api.miro.com/v2",
-		Timeout: 15 * time.Second,
+		BaseURL:          "https://api.miro.com/v2",
+		Timeout:          15 * time.Second,
+		CreditReserve:    10000,
+		MaxRateLimitWait: 5 * time.Second,
 	}
 }
 
@@ -50,6 +58,22 @@ func (c *MiroConfig) loadEnv() error {
 		}
 	}
 
+	if reserve := os.Getenv("MIRO_CREDIT_RESERVE"); reserve != "" {
+		var reserveInt int
+		if _, err := fmt.Sscanf(reserve, "%d", &reserveInt); err != nil {
+			return fmt.Errorf("invalid credit reserve: %w", err)
+		}
+		c.CreditReserve = reserveInt
+	}
+
+	if wait := os.Getenv("MIRO_MAX_RATE_LIMIT_WAIT"); wait != "" {
+		if duration, err := time.ParseDuration(wait); err != nil {
+			return fmt.Errorf("invalid max rate limit wait duration: %w", err)
+		} else {
+			c.MaxRateLimitWait = duration
+		}
+	}
+
 	return nil
 }
 
@@ -64,6 +88,10 @@ func (c *MiroConfig) Validate() error {
 					return fmt.Errorf("base_url is required")
 				case "Timeout":
 					return fmt.Errorf("timeout is required")
+				case "CreditReserve":
+					return fmt.Errorf("credit_reserve must be non-negative")
+				case "MaxRateLimitWait":
+					return fmt.Errorf("max_rate_limit_wait must be non-negative")
 				default:
 					return fmt.Errorf("validation error on field %s: %s", e.Field(), e.Tag())
 				}
@@ -27,18 +27,28 @@ import (
 )
 
 type OAuthConfig struct {
-	ClientID         string        `yaml:"client_id" env:"OAUTH_CLIENT_ID" validate:"required"`
-	ClientSecret     string        `yaml:"client_secret" env:"OAUTH_CLIENT_SECRET" validate:"required"`
-	EncryptionSecret string        `yaml:"encryption_secret" env:"OAUTH_ENCRYPTION_SECRET" validate:"required"`
-	RedirectURI      string        `yaml:"redirect_uri" env:"OAUTH_REDIRECT_URI" validate:"required,http_address"`
-	TokenURI         string        `yaml:"token_uri" env:"OAUTH_TOKEN_URI" validate:"required,http_address"`
-	Timeout          time.Duration `yaml:"timeout" env:"OAUTH_TIMEOUT" validate:"required"`
+	ClientID         string `yaml:"client_id" env:"OAUTH_CLIENT_ID" validate:"required"`
+	ClientSecret     string `yaml:"client_secret" env:"OAUTH_CLIENT_SECRET" validate:"required"`
+	EncryptionSecret string `yaml:"encryption_secret" env:"OAUTH_ENCRYPTION_SECRET" validate:"required"`
+	// PreviousEncryptionSecrets are retired secrets still accepted for
+	// decryption until every stored value is re-encrypted.
+	PreviousEncryptionSecrets []string      `yaml:"previous_encryption_secrets" env:"OAUTH_PREVIOUS_ENCRYPTION_SECRETS"`
+	RedirectURI               string        `yaml:"redirect_uri" env:"OAUTH_REDIRECT_URI" validate:"required,http_address"`
+	TokenURI                  string        `yaml:"token_uri" env:"OAUTH_TOKEN_URI" validate:"required,http_address"`
+	RevokeURI                 string        `yaml:"revoke_uri" env:"OAUTH_REVOKE_URI" validate:"required,http_address"`
+	Timeout                   time.Duration `yaml:"timeout" env:"OAUTH_TIMEOUT" validate:"required"`
+	RefreshInterval           time.Duration `yaml:"refresh_interval" env:"OAUTH_REFRESH_INTERVAL" validate:"min=0"`
+	RefreshAhead              time.Duration `yaml:"refresh_ahead" env:"OAUTH_REFRESH_AHEAD" validate:"min=0"`
+	PKCE                      bool          `yaml:"pkce" env:"OAUTH_PKCE"`
 }
 
 func DefaultOAuthConfig() *OAuthConfig {
 	return &OAuthConfig{
-		TokenURI: "https://api.miro.com/v1/oauth/token",
-		Timeout:  4 * time.Second,
+		TokenURI:        "https://api.miro.com/v1/oauth/token",
+		RevokeURI:       "https://api.miro.com/v2/oauth/revoke",
+		Timeout:         4 * time.Second,
+		RefreshInterval: 5 * time.Minute,
+		RefreshAhead:    15 * time.Minute,
 	}
 }
 
@@ -55,6 +65,15 @@ func (c *OAuthConfig) loadEnv() error {
 		c.EncryptionSecret = encryptionSecret
 	}
 
+	if previous := os.Getenv("OAUTH_PREVIOUS_ENCRYPTION_SECRETS"); previous != "" {
+		c.PreviousEncryptionSecrets = nil
+		for _, secret := range strings.Split(previous, ",") {
+			if secret = strings.TrimSpace(secret); secret != "" {
+				c.PreviousEncryptionSecrets = append(c.PreviousEncryptionSecrets, secret)
+			}
+		}
+	}
+
 	if redirectURI := os.Getenv("OAUTH_REDIRECT_URI"); redirectURI != "" {
 		c.RedirectURI = redirectURI
 	}
@@ -63,6 +82,10 @@ func (c *OAuthConfig) loadEnv() error {
 		c.TokenURI = tokenURI
 	}
 
+	if revokeURI := os.Getenv("OAUTH_REVOKE_URI"); revokeURI != "" {
+		c.RevokeURI = revokeURI
+	}
+
 	if timeout := os.Getenv("OAUTH_TIMEOUT"); timeout != "" {
 		if duration, err := time.ParseDuration(timeout); err != nil {
 			return fmt.Errorf("invalid timeout duration: %w", err)
@@ -71,6 +94,26 @@ func (c *OAuthConfig) loadEnv() error {
 		}
 	}
 
+	if interval := os.Getenv("OAUTH_REFRESH_INTERVAL"); interval != "" {
+		if duration, err := time.ParseDuration(interval); err != nil {
+			return fmt.Errorf("invalid refresh interval duration: %w", err)
+		} else {
+			c.RefreshInterval = duration
+		}
+	}
+
+	if ahead := os.Getenv("OAUTH_REFRESH_AHEAD"); ahead != "" {
+		if duration, err := time.ParseDuration(ahead); err != nil {
+			return fmt.Errorf("invalid refresh ahead duration: %w", err)
+		} else {
+			c.RefreshAhead = duration
+		}
+	}
+
+	if pkce := os.Getenv("OAUTH_PKCE"); pkce != "" {
+		c.PKCE = pkce == "true"
+	}
+
 	return nil
 }
 
@@ -112,8 +155,18 @@ func (c *OAuthConfig) Validate() error {
 					}
 
 					return fmt.Errorf("token_uri is required")
+				case "RevokeURI":
+					if e.Tag() == "http_address" {
+						return fmt.Errorf("revoke_uri must be an HTTP/HTTPS URL without trailing slash")
+					}
+
+					return fmt.Errorf("revoke_uri is required")
 				case "Timeout":
 					return fmt.Errorf("timeout is required")
+				case "RefreshInterval":
+					return fmt.Errorf("refresh_interval must not be negative")
+				case "RefreshAhead":
+					return fmt.Errorf("refresh_ahead must not be negative")
 				default:
 					return fmt.Errorf("validation error on field %s: %s", e.Field(), e.Tag())
 				}
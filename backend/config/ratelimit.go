@@ -27,20 +27,98 @@ import (
 	validator "github.com/go-playground/validator/v10"
 )
 
+const (
+	RateLimitKeyIP   = "ip"
+	RateLimitKeyUser = "user"
+	RateLimitKeyTeam = "team"
+)
+
+const (
+	RateLimitPolicyDefault = "default"
+	RateLimitPolicyAPI     = "api"
+	RateLimitPolicyEditor  = "editor"
+	RateLimitPolicyOAuth   = "oauth"
+)
+
+// RateLimitPolicy limits a group of routes. Key selects whether requests are
+// counted per client address, per authenticated user or per team.
+type RateLimitPolicy struct {
+	Rate   int           `yaml:"rate" validate:"gt=0"`
+	Window time.Duration `yaml:"window" validate:"gt=0"`
+	Key    string        `yaml:"key" validate:"oneof=ip user team"`
+}
+
+// RateLimitConfig holds the default limit, applied per client address, and
+// the named policies overriding it for specific route groups.
 type RateLimitConfig struct {
-	Rate      int           `yaml:"rate" env:"RATE_LIMIT_RATE" validate:"gt=0"`
-	Window    time.Duration `yaml:"window" env:"RATE_LIMIT_WINDOW" validate:"gt=0"`
-	SkipPaths []string      `yaml:"skip_paths" env:"RATE_LIMIT_SKIP_PATHS"`
+	Rate      int                         `yaml:"rate" env:"RATE_LIMIT_RATE" validate:"gt=0"`
+	Window    time.Duration               `yaml:"window" env:"RATE_LIMIT_WINDOW" validate:"gt=0"`
+	SkipPaths []string                    `yaml:"skip_paths" env:"RATE_LIMIT_SKIP_PATHS"`
+	Policies  map[string]*RateLimitPolicy `yaml:"policies" env:"RATE_LIMIT_POLICIES"`
 }
 
 func DefaultRateLimitConfig() *RateLimitConfig {
 	return &RateLimitConfig{
 		Rate:      100,
 		Window:    time.Minute,
-		SkipPaths: []string{"/health", "/metrics"},
+		SkipPaths: []string{"/health", "/metrics", "/api/callback", "/api/files/download"},
+		Policies: map[string]*RateLimitPolicy{
+			RateLimitPolicyAPI:    {Rate: 300, Window: time.Minute, Key: RateLimitKeyUser},
+			RateLimitPolicyEditor: {Rate: 60, Window: time.Minute, Key: RateLimitKeyUser},
+			RateLimitPolicyOAuth:  {Rate: 30, Window: time.Minute, Key: RateLimitKeyIP},
+		},
+	}
+}
+
+// Policy returns the named policy, falling back to the default limit keyed
+// by client address.
+func (c *RateLimitConfig) Policy(name string) *RateLimitPolicy {
+	if policy, ok := c.Policies[name]; ok && policy != nil {
+		return policy
+	}
+
+	return &RateLimitPolicy{
+		Rate:   c.Rate,
+		Window: c.Window,
+		Key:    RateLimitKeyIP,
 	}
 }
 
+// parseRateLimitPolicies parses policies written as name:key:rate:window and
+// separated by commas, e.g. "api:user:300:1m,oauth:ip:30:1m".
+func parseRateLimitPolicies(value string) (map[string]*RateLimitPolicy, error) {
+	policies := make(map[string]*RateLimitPolicy)
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		parts := strings.Split(entry, ":")
+		if len(parts) != 4 {
+			return nil, fmt.Errorf("invalid rate limit policy %q", entry)
+		}
+
+		rate, err := strconv.Atoi(parts[2])
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate in policy %q: %w", entry, err)
+		}
+
+		window, err := time.ParseDuration(parts[3])
+		if err != nil {
+			return nil, fmt.Errorf("invalid window in policy %q: %w", entry, err)
+		}
+
+		policies[parts[0]] = &RateLimitPolicy{
+			Rate:   rate,
+			Window: window,
+			Key:    parts[1],
+		}
+	}
+
+	return policies, nil
+}
+
 func (c *RateLimitConfig) loadEnv() error {
 	if rate := os.Getenv("RATE_LIMIT_RATE"); rate != "" {
 		if r, err := strconv.Atoi(rate); err == nil {
@@ -58,6 +136,14 @@ func (c *RateLimitConfig) loadEnv() error {
 		c.SkipPaths = strings.Split(skipPaths, ",")
 	}
 
+	if policies := os.Getenv("RATE_LIMIT_POLICIES"); policies != "" {
+		parsed, err := parseRateLimitPolicies(policies)
+		if err != nil {
+			return err
+		}
+		c.Policies = parsed
+	}
+
 	return nil
 }
 
@@ -81,5 +167,30 @@ func (c *RateLimitConfig) Validate() error {
 		return err
 	}
 
+	for name, policy := range c.Policies {
+		if policy == nil {
+			return fmt.Errorf("rate limit policy %s is empty", name)
+		}
+
+		if err := validate.Struct(policy); err != nil {
+			if validationErrors, ok := err.(validator.ValidationErrors); ok {
+				for _, e := range validationErrors {
+					switch e.Field() {
+					case "Rate":
+						return fmt.Errorf("rate limit policy %s rate must be greater than 0", name)
+					case "Window":
+						return fmt.Errorf("rate limit policy %s window must be greater than 0", name)
+					case "Key":
+						return fmt.Errorf("rate limit policy %s key must be one of ip, user or team", name)
+					default:
+						return fmt.Errorf("validation error on field %s: %s", e.Field(), e.Tag())
+					}
+				}
+			}
+
+			return err
+		}
+	}
+
 	return nil
 }
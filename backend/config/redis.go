@@ -20,30 +20,64 @@ package config
 import (
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	validator "github.com/go-playground/validator/v10"
 )
 
+const (
+	RedisModeStandalone = "standalone"
+	RedisModeSentinel   = "sentinel"
+	RedisModeCluster    = "cluster"
+)
+
+// RedisConfig describes how to reach Redis. Standalone mode connects to
+// Host and Port, while sentinel and cluster modes use Addresses to reach
+// the sentinels or the cluster seed nodes.
 type RedisConfig struct {
-	Host     string        `yaml:"host" env:"REDIS_HOST" validate:"required"`
-	Port     int           `yaml:"port" env:"REDIS_PORT" validate:"gt=0"`
-	Password string        `yaml:"password" env:"REDIS_PASSWORD"`
-	DB       int           `yaml:"db" env:"REDIS_DB" validate:"min=0"`
-	Timeout  time.Duration `yaml:"timeout" env:"REDIS_TIMEOUT" validate:"required"`
+	Mode                  string        `yaml:"mode" env:"REDIS_MODE" validate:"oneof=standalone sentinel cluster"`
+	Host                  string        `yaml:"host" env:"REDIS_HOST" validate:"required_if=Mode standalone"`
+	Port                  int           `yaml:"port" env:"REDIS_PORT" validate:"gt=0"`
+	Addresses             []string      `yaml:"addresses" env:"REDIS_ADDRESSES" validate:"required_unless=Mode standalone"`
+	MasterName            string        `yaml:"master_name" env:"REDIS_MASTER_NAME" validate:"required_if=Mode sentinel"`
+	Username              string        `yaml:"username" env:"REDIS_USERNAME"`
+	Password              string        `yaml:"password" env:"REDIS_PASSWORD"`
+	SentinelUsername      string        `yaml:"sentinel_username" env:"REDIS_SENTINEL_USERNAME"`
+	SentinelPassword      string        `yaml:"sentinel_password" env:"REDIS_SENTINEL_PASSWORD"`
+	DB                    int           `yaml:"db" env:"REDIS_DB" validate:"min=0"`
+	TLS                   bool          `yaml:"tls" env:"REDIS_TLS"`
+	TLSCAFile             string        `yaml:"tls_ca_file" env:"REDIS_TLS_CA_FILE"`
+	TLSInsecureSkipVerify bool          `yaml:"tls_insecure_skip_verify" env:"REDIS_TLS_INSECURE_SKIP_VERIFY"`
+	Timeout               time.Duration `yaml:"timeout" env:"REDIS_TIMEOUT" validate:"required"`
+	FailureThreshold      int           `yaml:"failure_threshold" env:"REDIS_FAILURE_THRESHOLD" validate:"gt=0"`
+	RecoveryTimeout       time.Duration `yaml:"recovery_timeout" env:"REDIS_RECOVERY_TIMEOUT" validate:"gt=0"`
 }
 
 func DefaultRedisConfig() *RedisConfig {
 	return &RedisConfig{
+		Mode:     RedisModeStandalone,
 		Host:     "localhost",
 		Port:     6379,
 		Password: "",
 		DB:       0,
 		Timeout:  5 * time.Second,
+
+		FailureThreshold: 5,
+		RecoveryTimeout:  30 * time.Second,
 	}
 }
 
+// Address returns the standalone server address.
+func (c *RedisConfig) Address() string {
+	return fmt.Sprintf("%s:%d", c.Host, c.Port)
+}
+
 func (c *RedisConfig) loadEnv() error {
+	if mode := os.Getenv("REDIS_MODE"); mode != "" {
+		c.Mode = mode
+	}
+
 	if host := os.Getenv("REDIS_HOST"); host != "" {
 		c.Host = host
 	}
@@ -56,10 +90,35 @@ func (c *RedisConfig) loadEnv() error {
 		c.Port = portInt
 	}
 
+	if addresses := os.Getenv("REDIS_ADDRESSES"); addresses != "" {
+		c.Addresses = nil
+		for _, address := range strings.Split(addresses, ",") {
+			if address = strings.TrimSpace(address); address != "" {
+				c.Addresses = append(c.Addresses, address)
+			}
+		}
+	}
+
+	if masterName := os.Getenv("REDIS_MASTER_NAME"); masterName != "" {
+		c.MasterName = masterName
+	}
+
+	if username := os.Getenv("REDIS_USERNAME"); username != "" {
+		c.Username = username
+	}
+
 	if password := os.Getenv("REDIS_PASSWORD"); password != "" {
 		c.Password = password
 	}
 
+	if username := os.Getenv("REDIS_SENTINEL_USERNAME"); username != "" {
+		c.SentinelUsername = username
+	}
+
+	if password := os.Getenv("REDIS_SENTINEL_PASSWORD"); password != "" {
+		c.SentinelPassword = password
+	}
+
 	if db := os.Getenv("REDIS_DB"); db != "" {
 		var dbInt int
 		if _, err := fmt.Sscanf(db, "%d", &dbInt); err != nil {
@@ -68,6 +127,18 @@ func (c *RedisConfig) loadEnv() error {
 		c.DB = dbInt
 	}
 
+	if tls := os.Getenv("REDIS_TLS"); tls != "" {
+		c.TLS = tls == "true"
+	}
+
+	if caFile := os.Getenv("REDIS_TLS_CA_FILE"); caFile != "" {
+		c.TLSCAFile = caFile
+	}
+
+	if skipVerify := os.Getenv("REDIS_TLS_INSECURE_SKIP_VERIFY"); skipVerify != "" {
+		c.TLSInsecureSkipVerify = skipVerify == "true"
+	}
+
 	if timeout := os.Getenv("REDIS_TIMEOUT"); timeout != "" {
 		if duration, err := time.ParseDuration(timeout); err != nil {
 			return fmt.Errorf("invalid timeout duration: %w", err)
@@ -76,6 +147,22 @@ func (c *RedisConfig) loadEnv() error {
 		}
 	}
 
+	if threshold := os.Getenv("REDIS_FAILURE_THRESHOLD"); threshold != "" {
+		var thresholdInt int
+		if _, err := fmt.Sscanf(threshold, "%d", &thresholdInt); err != nil {
+			return fmt.Errorf("invalid failure threshold: %w", err)
+		}
+		c.FailureThreshold = thresholdInt
+	}
+
+	if timeout := os.Getenv("REDIS_RECOVERY_TIMEOUT"); timeout != "" {
+		if duration, err := time.ParseDuration(timeout); err != nil {
+			return fmt.Errorf("invalid recovery timeout duration: %w", err)
+		} else {
+			c.RecoveryTimeout = duration
+		}
+	}
+
 	return nil
 }
 
@@ -86,14 +173,24 @@ func (c *RedisConfig) Validate() error {
 		if validationErrors, ok := err.(validator.ValidationErrors); ok {
 			for _, e := range validationErrors {
 				switch e.Field() {
+				case "Mode":
+					return fmt.Errorf("mode must be one of standalone, sentinel or cluster")
 				case "Host":
 					return fmt.Errorf("host is required")
 				case "Port":
 					return fmt.Errorf("port must be positive")
+				case "Addresses":
+					return fmt.Errorf("addresses are required in sentinel and cluster modes")
+				case "MasterName":
+					return fmt.Errorf("master_name is required in sentinel mode")
 				case "DB":
 					return fmt.Errorf("db must be non-negative")
 				case "Timeout":
 					return fmt.Errorf("timeout is required")
+				case "FailureThreshold":
+					return fmt.Errorf("failure_threshold must be positive")
+				case "RecoveryTimeout":
+					return fmt.Errorf("recovery_timeout must be positive")
 				default:
 					return fmt.Errorf("validation error on field %s: %s", e.Field(), e.Tag())
 				}
@@ -102,5 +199,9 @@ func (c *RedisConfig) Validate() error {
 		return err
 	}
 
+	if c.Mode == RedisModeCluster && c.DB != 0 {
+		return fmt.Errorf("db must be 0 in cluster mode")
+	}
+
 	return nil
 }
@@ -19,21 +19,26 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"strings"
+	"time"
 
 	validator "github.com/go-playground/validator/v10"
 )
 
 type ServerConfig struct {
-	Domain      string `yaml:"domain" env:"SERVER_DOMAIN" validate:"required"`
-	CallbackURL string `yaml:"callback_url" env:"CALLBACK_URL" validate:"required,http_address"`
+	Domain               string        `yaml:"domain" env:"SERVER_DOMAIN" validate:"required"`
+	CallbackURL          string        `yaml:"callback_url" env:"CALLBACK_URL" validate:"required,http_address"`
+	DownloadURL          string        `yaml:"download_url" env:"DOWNLOAD_URL" validate:"omitempty,http_address"`
+	SecretRotationWindow time.Duration `yaml:"secret_rotation_window" env:"SECRET_ROTATION_WINDOW" validate:"min=0"`
 }
 
 func DefaultServerConfig() *ServerConfig {
 	return &ServerConfig{
-		Domain:      "localhost",
-		CallbackURL: "http://localhost:8080/api/callback",
+		Domain:               "localhost",
+		CallbackURL:          "http://localhost:8080/api/callback",
+		SecretRotationWindow: 24 * time.Hour,
 	}
 }
 
@@ -46,6 +51,18 @@ func (c *ServerConfig) loadEnv() error {
 		c.CallbackURL = callbackUrl
 	}
 
+	if downloadUrl := os.Getenv("DOWNLOAD_URL"); downloadUrl != "" {
+		c.DownloadURL = downloadUrl
+	}
+
+	if window := os.Getenv("SECRET_ROTATION_WINDOW"); window != "" {
+		if duration, err := time.ParseDuration(window); err != nil {
+			return fmt.Errorf("invalid secret rotation window duration: %w", err)
+		} else {
+			c.SecretRotationWindow = duration
+		}
+	}
+
 	return nil
 }
 
@@ -77,6 +94,10 @@ func (c *ServerConfig) Validate() error {
 					}
 
 					return fmt.Errorf("callback_url is required")
+				case "DownloadURL":
+					return fmt.Errorf("download_url must be an HTTP/HTTPS URL without trailing slash")
+				case "SecretRotationWindow":
+					return fmt.Errorf("secret_rotation_window must not be negative")
 				default:
 					return fmt.Errorf("validation error on field %s: %s", e.Field(), e.Tag())
 				}
@@ -88,3 +109,19 @@ func (c *ServerConfig) Validate() error {
 
 	return nil
 }
+
+// FileDownloadURL returns the address Document Servers use to fetch files
+// through the backend. When download_url is not set, it is derived from the
+// callback URL host.
+func (c *ServerConfig) FileDownloadURL() string {
+	if c.DownloadURL != "" {
+		return c.DownloadURL
+	}
+
+	u, err := url.Parse(c.CallbackURL)
+	if err != nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%s://%s/api/files/download", u.Scheme, u.Host)
+}
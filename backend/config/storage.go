@@ -0,0 +1,135 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package config
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	validator "github.com/go-playground/validator/v10"
+)
+
+const (
+	CacheBackendRedis      = "redis"
+	CacheBackendMemory     = "memory"
+	CacheBackendChained    = "chained"
+	StorageBackendPostgres = "postgres"
+	StorageBackendFile     = "file"
+)
+
+// StorageConfig selects the cache and database backends. The memory cache
+// and the file database let a single instance run without Redis and
+// Postgres; neither can be shared between replicas. The chained cache keeps
+// up to CacheCapacity entries in process for at most LocalCacheExpiration
+// in front of Redis.
+type StorageConfig struct {
+	Cache                string        `yaml:"cache" env:"STORAGE_CACHE" validate:"oneof=redis memory chained"`
+	CacheCapacity        int           `yaml:"cache_capacity" env:"STORAGE_CACHE_CAPACITY" validate:"gt=0"`
+	LocalCacheExpiration time.Duration `yaml:"local_cache_expiration" env:"STORAGE_LOCAL_CACHE_EXPIRATION" validate:"gt=0"`
+	Database             string        `yaml:"database" env:"STORAGE_DATABASE" validate:"oneof=postgres file"`
+	Path                 string        `yaml:"path" env:"STORAGE_PATH" validate:"required_if=Database file"`
+}
+
+func DefaultStorageConfig() *StorageConfig {
+	return &StorageConfig{
+		Cache:                CacheBackendRedis,
+		CacheCapacity:        10000,
+		LocalCacheExpiration: 30 * time.Second,
+		Database:             StorageBackendPostgres,
+		Path:                 "data/storage.json",
+	}
+}
+
+// InMemoryCache reports whether caching, locking and rate limiting are kept
+// in process instead of Redis.
+func (c *StorageConfig) InMemoryCache() bool {
+	return c.Cache == CacheBackendMemory
+}
+
+// ChainedCache reports whether an in-process cache is kept in front of Redis.
+func (c *StorageConfig) ChainedCache() bool {
+	return c.Cache == CacheBackendChained
+}
+
+// FileDatabase reports whether records are kept in a local file instead of
+// Postgres.
+func (c *StorageConfig) FileDatabase() bool {
+	return c.Database == StorageBackendFile
+}
+
+func (c *StorageConfig) loadEnv() error {
+	if cache := os.Getenv("STORAGE_CACHE"); cache != "" {
+		c.Cache = cache
+	}
+
+	if capacity := os.Getenv("STORAGE_CACHE_CAPACITY"); capacity != "" {
+		var capacityInt int
+		if _, err := fmt.Sscanf(capacity, "%d", &capacityInt); err != nil {
+			return fmt.Errorf("invalid cache capacity: %w", err)
+		}
+		c.CacheCapacity = capacityInt
+	}
+
+	if expiration := os.Getenv("STORAGE_LOCAL_CACHE_EXPIRATION"); expiration != "" {
+		if duration, err := time.ParseDuration(expiration); err != nil {
+			return fmt.Errorf("invalid local cache expiration duration: %w", err)
+		} else {
+			c.LocalCacheExpiration = duration
+		}
+	}
+
+	if database := os.Getenv("STORAGE_DATABASE"); database != "" {
+		c.Database = database
+	}
+
+	if path := os.Getenv("STORAGE_PATH"); path != "" {
+		c.Path = path
+	}
+
+	return nil
+}
+
+func (c *StorageConfig) Validate() error {
+	validate := validator.New()
+
+	if err := validate.Struct(c); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, e := range validationErrors {
+				switch e.Field() {
+				case "Cache":
+					return fmt.Errorf("cache must be one of redis, memory or chained")
+				case "CacheCapacity":
+					return fmt.Errorf("cache_capacity must be positive")
+				case "LocalCacheExpiration":
+					return fmt.Errorf("local_cache_expiration must be positive")
+				case "Database":
+					return fmt.Errorf("database must be either postgres or file")
+				case "Path":
+					return fmt.Errorf("path is required for the file database")
+				default:
+					return fmt.Errorf("validation error on field %s: %s", e.Field(), e.Tag())
+				}
+			}
+		}
+
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package config
+
+import (
+	"fmt"
+	"os"
+
+	validator "github.com/go-playground/validator/v10"
+)
+
+const (
+	TracingExporterOTLP   = "otlp"
+	TracingExporterStdout = "stdout"
+)
+
+// TracingConfig configures OpenTelemetry tracing. Spans are exported over
+// OTLP/HTTP, or written to stdout for local testing.
+type TracingConfig struct {
+	Enabled  bool   `yaml:"enabled" env:"TRACING_ENABLED"`
+	Exporter string `yaml:"exporter" env:"TRACING_EXPORTER" validate:"oneof=otlp stdout"`
+	// Endpoint is the host and port of the OTLP/HTTP collector. When empty the
+	// standard OTEL_EXPORTER_OTLP_* environment variables apply.
+	Endpoint    string  `yaml:"endpoint" env:"TRACING_ENDPOINT" validate:"omitempty,hostname_port"`
+	Insecure    bool    `yaml:"insecure" env:"TRACING_INSECURE"`
+	SampleRatio float64 `yaml:"sample_ratio" env:"TRACING_SAMPLE_RATIO" validate:"min=0,max=1"`
+}
+
+func DefaultTracingConfig() *TracingConfig {
+	return &TracingConfig{
+		Enabled:     false,
+		Exporter:    TracingExporterOTLP,
+		SampleRatio: 1,
+	}
+}
+
+func (c *TracingConfig) loadEnv() error {
+	if enabled := os.Getenv("TRACING_ENABLED"); enabled != "" {
+		c.Enabled = enabled == "true"
+	}
+
+	if exporter := os.Getenv("TRACING_EXPORTER"); exporter != "" {
+		c.Exporter = exporter
+	}
+
+	if endpoint := os.Getenv("TRACING_ENDPOINT"); endpoint != "" {
+		c.Endpoint = endpoint
+	}
+
+	if insecure := os.Getenv("TRACING_INSECURE"); insecure != "" {
+		c.Insecure = insecure == "true"
+	}
+
+	if ratio := os.Getenv("TRACING_SAMPLE_RATIO"); ratio != "" {
+		var ratioFloat float64
+		if _, err := fmt.Sscanf(ratio, "%f", &ratioFloat); err != nil {
+			return fmt.Errorf("invalid sample ratio: %w", err)
+		}
+		c.SampleRatio = ratioFloat
+	}
+
+	return nil
+}
+
+func (c *TracingConfig) Validate() error {
+	validate := validator.New()
+
+	if err := validate.Struct(c); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, e := range validationErrors {
+				switch e.Field() {
+				case "Exporter":
+					return fmt.Errorf("tracing exporter must be one of: otlp, stdout")
+				case "Endpoint":
+					return fmt.Errorf("tracing endpoint must be a host and port")
+				case "SampleRatio":
+					return fmt.Errorf("sample_ratio must be between 0 and 1")
+				default:
+					return fmt.Errorf("validation error on field %s: %s", e.Field(), e.Tag())
+				}
+			}
+		}
+
+		return err
+	}
+
+	return nil
+}
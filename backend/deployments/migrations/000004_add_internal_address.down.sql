@@ -0,0 +1 @@
+ALTER TABLE settings DROP COLUMN IF EXISTS internal_address;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE settings ADD COLUMN IF NOT EXISTS internal_address VARCHAR(255) NOT NULL DEFAULT '';
\ No newline at end of file
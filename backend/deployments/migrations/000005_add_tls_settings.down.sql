@@ -0,0 +1 @@
+ALTER TABLE settings DROP COLUMN IF EXISTS tls;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE settings ADD COLUMN IF NOT EXISTS tls JSONB NOT NULL DEFAULT '{}'::jsonb;
\ No newline at end of file
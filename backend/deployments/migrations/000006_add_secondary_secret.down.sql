@@ -0,0 +1 @@
+ALTER TABLE settings DROP COLUMN IF EXISTS secondary_secret; ALTER TABLE settings DROP COLUMN IF EXISTS secondary_expires_at;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE settings ADD COLUMN IF NOT EXISTS secondary_secret TEXT NOT NULL DEFAULT ''; ALTER TABLE settings ADD COLUMN IF NOT EXISTS secondary_expires_at TIMESTAMP WITH TIME ZONE;
\ No newline at end of file
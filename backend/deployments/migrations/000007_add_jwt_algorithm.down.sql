@@ -0,0 +1 @@
+ALTER TABLE settings DROP COLUMN IF EXISTS jwt_algorithm;
\ No newline at end of file
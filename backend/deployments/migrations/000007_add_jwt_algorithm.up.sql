@@ -0,0 +1 @@
+ALTER TABLE settings ADD COLUMN IF NOT EXISTS jwt_algorithm VARCHAR(16) NOT NULL DEFAULT 'HS256';
\ No newline at end of file
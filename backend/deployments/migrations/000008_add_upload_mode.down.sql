@@ -0,0 +1 @@
+ALTER TABLE settings DROP COLUMN IF EXISTS upload_mode;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE settings ADD COLUMN IF NOT EXISTS upload_mode VARCHAR(16) NOT NULL DEFAULT 'url';
\ No newline at end of file
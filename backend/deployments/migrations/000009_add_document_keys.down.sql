@@ -0,0 +1 @@
+DROP TABLE IF EXISTS document_keys;
\ No newline at end of file
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS document_keys (
+    board_id TEXT NOT NULL,
+    file_id TEXT NOT NULL,
+    key VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (board_id, file_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_document_keys_updated_at ON document_keys(updated_at);
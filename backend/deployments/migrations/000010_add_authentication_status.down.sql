@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_authentications_expires_at; ALTER TABLE authentications DROP COLUMN IF EXISTS refreshed_at; ALTER TABLE authentications DROP COLUMN IF EXISTS status;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE authentications ADD COLUMN IF NOT EXISTS status VARCHAR(16) NOT NULL DEFAULT 'active'; ALTER TABLE authentications ADD COLUMN IF NOT EXISTS refreshed_at TIMESTAMP WITH TIME ZONE; CREATE INDEX IF NOT EXISTS idx_authentications_expires_at ON authentications(expires_at);
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE authentications DROP COLUMN IF EXISTS refresh_failures;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE authentications ADD COLUMN IF NOT EXISTS refresh_failures INTEGER NOT NULL DEFAULT 0;
\ No newline at end of file
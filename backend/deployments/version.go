@@ -0,0 +1,78 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package deployments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+
+	iofs "github.com/golang-migrate/migrate/v4/source/iofs"
+	pgx "github.com/jackc/pgx/v5"
+	pgxpool "github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ErrSchemaNotMigrated is returned when the database holds no migration state.
+var ErrSchemaNotMigrated = errors.New("database schema has not been migrated")
+
+// LatestVersion returns the version of the newest embedded migration.
+func LatestVersion() (uint, error) {
+	d, err := iofs.New(migrationsFS, "migrations")
+	if err != nil {
+		return 0, fmt.Errorf("failed to create migrations source: %w", err)
+	}
+	defer d.Close()
+
+	version, err := d.First()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read first migration: %w", err)
+	}
+
+	for {
+		next, err := d.Next(version)
+		if errors.Is(err, fs.ErrNotExist) {
+			return version, nil
+		}
+
+		if err != nil {
+			return 0, fmt.Errorf("failed to read next migration: %w", err)
+		}
+
+		version = next
+	}
+}
+
+// SchemaVersion reads the migration state recorded in the database.
+func SchemaVersion(ctx context.Context, pool *pgxpool.Pool) (uint, bool, error) {
+	var (
+		version int64
+		dirty   bool
+	)
+
+	err := pool.QueryRow(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+	if errors.Is(err, pgx.ErrNoRows) || (err == nil && version < 0) {
+		return 0, false, ErrSchemaNotMigrated
+	}
+
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to read schema version: %w", err)
+	}
+
+	return uint(version), dirty, nil
+}
@@ -28,11 +28,16 @@ import (
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/client/oauth"
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/common"
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/controller"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/breaker"
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/document"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/metrics"
 	oauthService "github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/oauth"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/registry"
 	settingsService "github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/settings"
 	pgxpool "github.com/jackc/pgx/v5/pgxpool"
 	echo "github.com/labstack/echo/v4"
+	redis "github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/trace"
 )
 
 var _ core.AuthCompositeKey
@@ -58,7 +63,34 @@ func (a *App) SetupRoutes(logger service.Logger) {
 type Database struct {
 	Pool            *pgxpool.Pool
 	AuthStorage     service.Storage[core.AuthCompositeKey, component.Authentication]
+	AuthLister      service.Lister[core.AuthCompositeKey, component.Authentication, core.AuthFilter]
 	SettingsStorage service.Storage[core.SettingsCompositeKey, component.Settings]
+	SettingsLister  service.Lister[core.SettingsCompositeKey, component.Settings, core.SettingsFilter]
+	KeyStorage      service.Storage[core.DocumentKeyCompositeKey, component.DocumentKey]
+	KeySweeper      service.Sweeper
+}
+
+// Breakers holds the circuit breakers guarding Redis and every upstream host
+// of the external API clients.
+type Breakers struct {
+	Redis     *breaker.Breaker
+	Miro      *breaker.Registry
+	OAuth     *breaker.Registry
+	DocServer *breaker.Registry
+}
+
+// Statuses reports the state of every breaker for observability.
+func (b *Breakers) Statuses() []breaker.Status {
+	var statuses []breaker.Status
+	if b.Redis != nil {
+		statuses = append(statuses, b.Redis.Status())
+	}
+
+	for _, registry := range []*breaker.Registry{b.Miro, b.OAuth, b.DocServer} {
+		statuses = append(statuses, registry.Statuses()...)
+	}
+
+	return statuses
 }
 
 // Clients contains all external API client instances.
@@ -74,24 +106,39 @@ type Services struct {
 	Builder         document.BuilderService
 	FormatManager   document.FormatManager
 	JwtService      crypto.Signer
+	KeyRegistry     registry.KeyRegistry
 	Renderer        *controller.TemplateRenderer
 	SettingsService settingsService.SettingsService
+	StateService    oauthService.StateService
 	Translator      service.TranslationProvider
 }
 
 // Controllers contains all HTTP request handlers.
 type Controllers struct {
+	Admin          common.Handler
 	Auth           common.Handler
+	Breakers       common.Handler
+	CacheStats     common.Handler
 	Callback       common.Handler
+	Disconnect     common.Handler
 	Editor         common.Handler
+	Install        common.Handler
+	Liveness       common.Handler
+	Readiness      common.Handler
 	FileConversion common.Handler
+	FileDownload   common.Handler
 	FileManagement common.Handler
 	Settings       common.Handler
+	Uninstall      common.Handler
 }
 
 // Router provides access to the Echo instance and configuration.
 type Router struct {
-	Config   *config.Config
-	Echo     *echo.Echo
-	Services *Services
+	Config       *config.Config
+	Echo         *echo.Echo
+	Redis        redis.UniversalClient
+	RedisBreaker *breaker.Breaker
+	Metrics      *metrics.Metrics
+	Tracer       trace.TracerProvider
+	Services     *Services
 }
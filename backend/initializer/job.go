@@ -0,0 +1,80 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package initializer
+
+import (
+	"context"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/config"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/service"
+)
+
+const (
+	documentKeyCollectionInterval = time.Hour
+	secretReencryptionInterval    = 6 * time.Hour
+)
+
+// runPeriodically calls fn every interval until ctx is cancelled.
+func runPeriodically(ctx context.Context, interval time.Duration, fn func(context.Context)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			fn(ctx)
+		}
+	}
+}
+
+// collectDocumentKeys removes document keys whose editing sessions were
+// never closed by a Document Server callback.
+func collectDocumentKeys(services *Services, logger service.Logger) func(context.Context) {
+	return func(ctx context.Context) {
+		if _, err := services.KeyRegistry.Collect(ctx); err != nil {
+			logger.Warn(ctx, "Failed to collect stale document keys", service.Fields{"error": err.Error()})
+		}
+	}
+}
+
+// refreshExpiringTokens refreshes OAuth tokens ahead of their expiration so
+// that requests rarely have to wait for a refresh.
+func refreshExpiringTokens(config *config.Config, services *Services, logger service.Logger) func(context.Context) {
+	return func(ctx context.Context) {
+		if _, err := services.AuthService.RefreshExpiring(ctx, config.OAuth.RefreshAhead); err != nil {
+			logger.Warn(ctx, "Failed to refresh expiring OAuth tokens", service.Fields{"error": err.Error()})
+		}
+	}
+}
+
+// reencryptSecrets rewrites stored tokens and settings that are still
+// encrypted with a previous encryption secret.
+func reencryptSecrets(services *Services, logger service.Logger) func(context.Context) {
+	return func(ctx context.Context) {
+		if _, err := services.AuthService.Reencrypt(ctx); err != nil {
+			logger.Warn(ctx, "Failed to re-encrypt OAuth tokens", service.Fields{"error": err.Error()})
+		}
+
+		if _, err := services.SettingsService.Reencrypt(ctx); err != nil {
+			logger.Warn(ctx, "Failed to re-encrypt settings", service.Fields{"error": err.Error()})
+		}
+	}
+}
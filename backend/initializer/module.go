@@ -30,20 +30,36 @@ import (
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/client/miro"
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/client/oauth"
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/controller"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/controller/admin"
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/controller/auth"
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/controller/callback"
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/controller/editor"
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/controller/file"
+	healthController "github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/controller/health"
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/controller/settings"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/breaker"
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/cache"
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/document"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/health"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/kms"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/lock"
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/logger"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/metrics"
 	oauthService "github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/oauth"
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/processor"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/registry"
 	settingsService "github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/settings"
+	fileStore "github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/storage/file"
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/storage/pg"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/tracing"
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/translation"
+	jwt "github.com/golang-jwt/jwt/v5"
 	echo "github.com/labstack/echo/v4"
+	redis "github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 	fx "go.uber.org/fx"
 )
 
@@ -57,15 +73,23 @@ import (
 var Module = fx.Options(
 	fx.Provide(
 		// Infrastructure layer - fundamental services
-		NewLogger,   // Base logging service first
-		NewDatabase, // Database connection and storage services
-		NewCache,    // Caching service
+		NewLogger,       // Base logging service first
+		NewMetrics,      // Prometheus metrics
+		NewTracing,      // OpenTelemetry tracing
+		NewDatabase,     // Database connection and storage services
+		NewRedisBreaker, // Health of the shared Redis connection
+		NewRedisClient,  // Shared Redis connection
+		NewCache,        // Caching service
+		NewLocker,       // Distributed locking service
+		NewCipher,       // Encryption of stored secrets
 
 		// External clients layer
-		NewClients, // External API client services (Miro, OAuth, DocServer)
+		NewBreakers, // Circuit breakers and bulkheads per upstream host
+		NewClients,  // External API client services (Miro, OAuth, DocServer)
 
 		// Application services layer - business logic
-		NewServices, // Core application services
+		NewServices,      // Core application services
+		NewHealthChecker, // Readiness checks of external dependencies
 
 		// Web layer - HTTP components
 		NewControllers, // Controller layer for handling requests
@@ -92,21 +116,199 @@ func NewLogger(config *config.LoggerConfig) service.Logger {
 	}
 }
 
+// NewMetrics creates the Prometheus metrics of the application.
+// It returns nil when metrics are disabled, which discards every observation.
+func NewMetrics(config *config.Config) (*metrics.Metrics, error) {
+	if !config.Metrics.Enabled {
+		return nil, nil
+	}
+
+	return metrics.NewMetrics()
+}
+
+// NewTracing creates the OpenTelemetry tracer provider and installs it, along
+// with the W3C trace context propagator, as the global one used by every
+// instrumented component. It returns a no-op provider when tracing is disabled.
+func NewTracing(lifecycle fx.Lifecycle, config *config.Config, logger service.Logger) (trace.TracerProvider, error) {
+	if !config.Tracing.Enabled {
+		return noop.NewTracerProvider(), nil
+	}
+
+	provider, err := tracing.NewTracerProvider(
+		context.Background(),
+		config.Tracing,
+		config.Logger.ServiceName,
+		config.Logger.Environment,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		logger.Warn(context.Background(), "OpenTelemetry error", service.Fields{"error": err.Error()})
+	}))
+
+	lifecycle.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			return provider.Shutdown(ctx)
+		},
+	})
+
+	return provider, nil
+}
+
+// NewRedisBreaker creates the circuit breaker tracking Redis health. The cache
+// and the rate limiter degrade to local state while it is open. It returns nil
+// when Redis is not used at all.
+func NewRedisBreaker(config *config.Config, logger service.Logger) (*breaker.Breaker, error) {
+	if config.Storage.InMemoryCache() {
+		return nil, nil
+	}
+
+	return breaker.NewBreaker(
+		"redis",
+		logger,
+		breaker.WithFailureThreshold(config.Redis.FailureThreshold),
+		breaker.WithRecoveryTimeout(config.Redis.RecoveryTimeout),
+	)
+}
+
+// NewRedisClient creates the Redis connection shared by the cache, the locker
+// and the rate limiter. It returns nil when Redis is not used at all.
+func NewRedisClient(
+	lifecycle fx.Lifecycle,
+	config *config.Config,
+	redisBreaker *breaker.Breaker,
+	logger service.Logger,
+) (redis.UniversalClient, error) {
+	if config.Storage.InMemoryCache() {
+		return nil, nil
+	}
+
+	client, err := cache.NewRedisClient(config.Redis, redisBreaker, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	lifecycle.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			return client.Close()
+		},
+	})
+
+	return client, nil
+}
+
 // NewCache creates a caching service.
-// It initializes a Redis-based cache with the application configuration.
-func NewCache(config *config.Config, logger service.Logger) (service.Cache, error) {
+// It initializes a Redis-based, in-memory or chained cache depending on the storage configuration.
+func NewCache(
+	lifecycle fx.Lifecycle,
+	config *config.Config,
+	client redis.UniversalClient,
+	redisBreaker *breaker.Breaker,
+	metrics *metrics.Metrics,
+	logger service.Logger,
+) (service.Cache, error) {
+	if config.Storage.InMemoryCache() {
+		return cache.NewMemoryCache(
+			logger,
+			cache.WithKeyPrefix("app:cache:"),
+			cache.WithDefaultExpiration(5*time.Minute),
+			cache.WithCapacity(config.Storage.CacheCapacity),
+			cache.WithMetrics(metrics),
+		)
+	}
+
 	// Create a Redis cache with default options
-	return cache.NewRedisCache(
-		config.Redis,
+	remote, err := cache.NewRedisCache(
+		client,
 		logger,
 		cache.WithKeyPrefix("app:cache:"),
 		cache.WithDefaultExpiration(5*time.Minute),
+		cache.WithBreaker(redisBreaker),
+		cache.WithMetrics(metrics),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if !config.Storage.ChainedCache() {
+		return remote, nil
+	}
+
+	local, err := cache.NewMemoryCache(
+		logger,
+		cache.WithDefaultExpiration(config.Storage.LocalCacheExpiration),
+		cache.WithCapacity(config.Storage.CacheCapacity),
+		cache.WithMetrics(metrics),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	chained, err := cache.NewChainedCache(local, remote, config.Storage.LocalCacheExpiration, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	lifecycle.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			return chained.Close()
+		},
+	})
+
+	return chained, nil
+}
+
+// NewLocker creates a distributed locking service.
+// It coordinates work that must not run concurrently across replicas.
+func NewLocker(config *config.Config, client redis.UniversalClient, logger service.Logger) (service.Locker, error) {
+	if config.Storage.InMemoryCache() {
+		return lock.NewMemoryLocker(logger, lock.WithKeyPrefix("app:lock:"))
+	}
+
+	return lock.NewRedisLocker(
+		client,
+		logger,
+		lock.WithKeyPrefix("app:lock:"),
 	)
 }
 
+// NewCipher creates the cipher protecting stored secrets. Envelope providers
+// fall back to the OAuth encryption secrets so that values written before
+// they were enabled stay readable until re-encrypted.
+func NewCipher(config *config.Config, logger service.Logger) (crypto.Cipher, error) {
+	previousSecrets := make([][]byte, 0, len(config.OAuth.PreviousEncryptionSecrets))
+	for _, secret := range config.OAuth.PreviousEncryptionSecrets {
+		previousSecrets = append(previousSecrets, []byte(secret))
+	}
+
+	keyring := crypto.NewKeyring([]byte(config.OAuth.EncryptionSecret), previousSecrets...)
+
+	if !config.Encryption.Envelope() {
+		return keyring, nil
+	}
+
+	manager, err := kms.NewKeyManager(config.Encryption, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto.NewEnvelopeCipher(manager, keyring), nil
+}
+
 // NewDatabase initializes the database connection pool and storage services.
 // It handles database migration and creates storage repositories.
 func NewDatabase(config *config.Config, logger service.Logger) (*Database, error) {
+	if config.Storage.FileDatabase() {
+		return newFileDatabase(config, logger)
+	}
+
 	pool, err := pg.NewPostgresPool(config.Database.DatasourceURL())
 	if err != nil {
 		return nil, err
@@ -127,15 +329,87 @@ func NewDatabase(config *config.Config, logger service.Logger) (*Database, error
 		return nil, err
 	}
 
+	authLister, err := pg.NewPostgresLister(pool, processor.NewAuthenticationListProcessor(), logger)
+	if err != nil {
+		return nil, err
+	}
+
 	settingsStorage, err := pg.NewPostgresStorage(pool, processor.NewSettingsProcessor(), logger)
 	if err != nil {
 		return nil, err
 	}
 
+	settingsLister, err := pg.NewPostgresLister(pool, processor.NewSettingsListProcessor(), logger)
+	if err != nil {
+		return nil, err
+	}
+
+	keyStorage, err := pg.NewPostgresStorage(pool, processor.NewDocumentKeyProcessor(), logger)
+	if err != nil {
+		return nil, err
+	}
+
+	keySweeper, err := pg.NewExpirationSweeper(pool, processor.NewDocumentKeyProcessor().TableName(), "updated_at", logger)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Database{
 		Pool:            pool,
 		AuthStorage:     authStorage,
+		AuthLister:      authLister,
 		SettingsStorage: settingsStorage,
+		SettingsLister:  settingsLister,
+		KeyStorage:      keyStorage,
+		KeySweeper:      keySweeper,
+	}, nil
+}
+
+// newFileDatabase creates storage services backed by a local file for
+// single-instance deployments without Postgres.
+func newFileDatabase(config *config.Config, logger service.Logger) (*Database, error) {
+	store, err := fileStore.NewFileStore(config.Storage.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	authStorage, err := fileStore.NewFileStorage(store, processor.NewAuthenticationFileProcessor(), logger)
+	if err != nil {
+		return nil, err
+	}
+
+	authLister, err := fileStore.NewFileLister(store, processor.NewAuthenticationFileListProcessor(), logger)
+	if err != nil {
+		return nil, err
+	}
+
+	settingsStorage, err := fileStore.NewFileStorage(store, processor.NewSettingsFileProcessor(), logger)
+	if err != nil {
+		return nil, err
+	}
+
+	settingsLister, err := fileStore.NewFileLister(store, processor.NewSettingsFileListProcessor(), logger)
+	if err != nil {
+		return nil, err
+	}
+
+	keyStorage, err := fileStore.NewFileStorage(store, processor.NewDocumentKeyFileProcessor(), logger)
+	if err != nil {
+		return nil, err
+	}
+
+	keySweeper, err := fileStore.NewExpirationSweeper(store, processor.NewDocumentKeyFileProcessor().TableName(), logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Database{
+		AuthStorage:     authStorage,
+		AuthLister:      authLister,
+		SettingsStorage: settingsStorage,
+		SettingsLister:  settingsLister,
+		KeyStorage:      keyStorage,
+		KeySweeper:      keySweeper,
 	}, nil
 }
 
@@ -145,19 +419,72 @@ func NewDatabase(config *config.Config, logger service.Logger) (*Database, error
 
 // NewClients initializes external API clients.
 // These are used to communicate with external services like Miro.
-func NewClients(config *config.Config, logger service.Logger) (*Clients, error) {
-	oauthClient, err := oauth.NewOAuthClient[miro.AuthenticationResponse](config.OAuth, logger)
+func NewClients(
+	config *config.Config,
+	breakers *Breakers,
+	metrics *metrics.Metrics,
+	logger service.Logger,
+) (*Clients, error) {
+	oauthClient, err := oauth.NewOAuthClient[miro.AuthenticationResponse](config.OAuth, breakers.OAuth, logger)
 	if err != nil {
 		return nil, err
 	}
 
 	return &Clients{
-		DocServer:   docserver.NewClient(logger),
-		MiroClient:  miro.NewMiroClient(config.Miro, logger),
+		DocServer:   docserver.NewClient(breakers.DocServer, metrics, logger),
+		MiroClient:  miro.NewMiroClient(config.Miro, breakers.Miro, metrics, logger),
 		OAuthClient: oauthClient,
 	}, nil
 }
 
+// NewBreakers creates the circuit breakers and bulkheads of the external API
+// clients, one per upstream host, and collects them with the Redis breaker.
+func NewBreakers(
+	config *config.Config,
+	redisBreaker *breaker.Breaker,
+	metrics *metrics.Metrics,
+	logger service.Logger,
+) (*Breakers, error) {
+	newRegistry := func(name string) (*breaker.Registry, error) {
+		return breaker.NewRegistry(
+			name,
+			config.Breaker.MaxConcurrent,
+			config.Breaker.MaxWait,
+			logger,
+			breaker.WithFailureThreshold(config.Breaker.FailureThreshold),
+			breaker.WithRecoveryTimeout(config.Breaker.RecoveryTimeout),
+		)
+	}
+
+	miroRegistry, err := newRegistry("miro")
+	if err != nil {
+		return nil, err
+	}
+
+	oauthRegistry, err := newRegistry("oauth")
+	if err != nil {
+		return nil, err
+	}
+
+	docServerRegistry, err := newRegistry("docserver")
+	if err != nil {
+		return nil, err
+	}
+
+	breakers := &Breakers{
+		Redis:     redisBreaker,
+		Miro:      miroRegistry,
+		OAuth:     oauthRegistry,
+		DocServer: docServerRegistry,
+	}
+
+	if err := metrics.RegisterBreakers(breakers); err != nil {
+		return nil, err
+	}
+
+	return breakers, nil
+}
+
 //
 // APPLICATION SERVICES LAYER
 //
@@ -169,10 +496,12 @@ func NewServices(
 	database *Database,
 	clients *Clients,
 	cache service.Cache,
+	locker service.Locker,
+	cipher crypto.Cipher,
+	metrics *metrics.Metrics,
 	logger service.Logger,
 ) (*Services, error) {
 	mapper := NewAuthenticationMapper()
-	cipher := crypto.NewAESCipher([]byte(config.OAuth.EncryptionSecret))
 	jwt := crypto.NewJwtService()
 
 	renderer, err := controller.NewTemplateRenderer(logger)
@@ -197,6 +526,16 @@ func NewServices(
 		clients.OAuthClient,
 		mapper,
 		database.AuthStorage,
+		database.AuthLister,
+		locker,
+		cache,
+		metrics,
+		logger,
+	)
+
+	stateService := oauthService.NewStateService(
+		[]byte(config.OAuth.ClientSecret),
+		cache,
 		logger,
 	)
 
@@ -207,6 +546,15 @@ func NewServices(
 		cipher,
 		jwt,
 		database.SettingsStorage,
+		database.SettingsLister,
+		locker,
+		logger,
+	)
+
+	keyRegistry := registry.NewKeyRegistry(
+		cache,
+		database.KeyStorage,
+		database.KeySweeper,
 		logger,
 	)
 
@@ -218,7 +566,9 @@ func NewServices(
 	return &Services{
 		AuthService:     authService,
 		SettingsService: settingsService,
+		StateService:    stateService,
 		JwtService:      jwt,
+		KeyRegistry:     keyRegistry,
 		Builder:         builder,
 		FormatManager:   formatManager,
 		Renderer:        &renderer,
@@ -226,6 +576,55 @@ func NewServices(
 	}, nil
 }
 
+// NewHealthChecker creates the readiness checks of the dependencies in use:
+// Postgres and its migration state, Redis and, when enabled, the demo
+// Document Server.
+func NewHealthChecker(
+	config *config.Config,
+	database *Database,
+	client redis.UniversalClient,
+	redisBreaker *breaker.Breaker,
+	clients *Clients,
+	services *Services,
+	logger service.Logger,
+) (*health.Checker, error) {
+	var checks []health.Check
+
+	if database.Pool != nil {
+		migrations, err := health.MigrationCheck(database.Pool)
+		if err != nil {
+			return nil, err
+		}
+
+		checks = append(checks, health.PostgresCheck(database.Pool), migrations)
+	}
+
+	if client != nil {
+		checks = append(checks, health.RedisCheck(client, redisBreaker))
+	}
+
+	if config.Health.CheckDemoServer {
+		token, err := services.JwtService.Create(jwt.MapClaims{
+			"payload": map[string]string{
+				"c": "version",
+			},
+		}, []byte(config.DemoServer.Secret))
+		if err != nil {
+			return nil, err
+		}
+
+		checks = append(checks, health.DocServerCheck(
+			"demo_server",
+			clients.DocServer,
+			config.DemoServer.Address,
+			config.DemoServer.Header,
+			token,
+		))
+	}
+
+	return health.NewChecker(config.Health.CacheTTL, config.Health.Timeout, logger, checks...), nil
+}
+
 //
 // WEB LAYER - CONTROLLERS AND FRAMEWORK
 //
@@ -235,7 +634,11 @@ func NewServices(
 func NewControllers(
 	config *config.Config,
 	clients *Clients,
+	breakers *Breakers,
 	services *Services,
+	cache service.Cache,
+	checker *health.Checker,
+	metrics *metrics.Metrics,
 	logger service.Logger,
 ) (*Controllers, error) {
 	editor := editor.NewEditorController(
@@ -245,6 +648,26 @@ func NewControllers(
 		services.Builder,
 		services.AuthService,
 		services.SettingsService,
+		services.KeyRegistry,
+		services.Translator,
+		logger,
+	)
+
+	disconnect := auth.NewDisconnectController(
+		services.AuthService,
+		logger,
+	)
+
+	uninstall := auth.NewUninstallController(
+		config,
+		services.AuthService,
+		services.SettingsService,
+		logger,
+	)
+
+	install := auth.NewInstallController(
+		config,
+		services.StateService,
 		services.Translator,
 		logger,
 	)
@@ -253,15 +676,20 @@ func NewControllers(
 		config,
 		clients.OAuthClient,
 		services.AuthService,
+		services.StateService,
+		services.Translator,
 		logger,
 	)
 
 	callback := callback.NewCallbackController(
 		config,
 		clients.MiroClient,
+		clients.DocServer,
 		services.JwtService,
 		services.AuthService,
 		services.SettingsService,
+		services.KeyRegistry,
+		metrics,
 		logger,
 	)
 
@@ -296,13 +724,45 @@ func NewControllers(
 		logger,
 	)
 
+	fileDownload := file.NewFileDownloadController(
+		config,
+		clients.MiroClient,
+		services.JwtService,
+		services.Builder,
+		services.AuthService,
+		services.SettingsService,
+		services.Translator,
+		logger,
+	)
+
+	cacheStats := admin.NewCacheStatsController(cache, logger)
+	breakerStatus := admin.NewBreakerStatusController(breakers, logger)
+
+	liveness := healthController.NewLivenessController()
+	readiness := healthController.NewReadinessController(checker)
+
+	admin := admin.NewTokenStatusController(
+		services.AuthService,
+		10*time.Second,
+		logger,
+	)
+
 	return &Controllers{
+		Admin:          admin,
+		CacheStats:     cacheStats,
 		Editor:         editor,
 		Auth:           auth,
+		Breakers:       breakerStatus,
+		Install:        install,
+		Liveness:       liveness,
+		Readiness:      readiness,
+		Disconnect:     disconnect,
+		Uninstall:      uninstall,
 		Callback:       callback,
 		Settings:       settings,
 		FileManagement: fileManagement,
 		FileConversion: fileConversion,
+		FileDownload:   fileDownload,
 	}, nil
 }
 
@@ -322,12 +782,20 @@ func NewEcho(services *Services) *echo.Echo {
 func NewRouter(
 	echo *echo.Echo,
 	config *config.Config,
+	redis redis.UniversalClient,
+	redisBreaker *breaker.Breaker,
+	metrics *metrics.Metrics,
+	tracer trace.TracerProvider,
 	services *Services,
 ) *Router {
 	return &Router{
-		Echo:     echo,
-		Config:   config,
-		Services: services,
+		Echo:         echo,
+		Config:       config,
+		Redis:        redis,
+		RedisBreaker: redisBreaker,
+		Metrics:      metrics,
+		Tracer:       tracer,
+		Services:     services,
 	}
 }
 
@@ -360,11 +828,24 @@ func NewApp(
 
 	app.SetupRoutes(logger)
 
+	jobs, stopJobs := context.WithCancel(context.Background())
 	lifecycle.Append(fx.Hook{
 		OnStart: func(ctx context.Context) error {
+			go runPeriodically(jobs, documentKeyCollectionInterval, collectDocumentKeys(services, logger))
+			if config.OAuth.RefreshInterval > 0 {
+				go runPeriodically(jobs, config.OAuth.RefreshInterval, refreshExpiringTokens(config, services, logger))
+			}
+			if len(config.OAuth.PreviousEncryptionSecrets) > 0 || config.Encryption.Envelope() {
+				reencrypt := reencryptSecrets(services, logger)
+				go func() {
+					reencrypt(jobs)
+					runPeriodically(jobs, secretReencryptionInterval, reencrypt)
+				}()
+			}
 			return nil
 		},
 		OnStop: func(ctx context.Context) error {
+			stopJobs()
 			return echo.Shutdown(ctx)
 		},
 	})
@@ -18,17 +18,19 @@
 package initializer
 
 import (
-	"log"
+	"context"
 	"net/http"
 	"strings"
 
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/assets"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/config"
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/service"
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/common"
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/middleware"
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/middleware/authentication"
 	echo "github.com/labstack/echo/v4"
 	echomiddleware "github.com/labstack/echo/v4/middleware"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
 )
 
 // SetupRoutes configures all application routes and middleware.
@@ -46,19 +48,45 @@ func (r *Router) SetupRoutes(
 	// Setup authentication middleware
 	authMiddleware, miroAuthMiddleware, editorMiddleware := setupAuthMiddleware(r, logger)
 
+	// Setup rate limiting
+	rateLimiter := setupRateLimitMiddleware(r, logger)
+
 	// Setup routes by category
-	setupEditorRoutes(r, controllers, editorMiddleware)
+	setupEditorRoutes(r, controllers, editorMiddleware, rateLimiter)
 	setupCallbackRoutes(r, controllers)
-	setupAuthRoutes(r, controllers)
-	setupProtectedRoutes(r, controllers, authMiddleware)
-	setupMiroAuthRoutes(r, miroAuthMiddleware)
+	setupDownloadRoutes(r, controllers)
+	setupAuthRoutes(r, controllers, rateLimiter)
+	setupAdminRoutes(r, controllers, rateLimiter, logger)
+	setupProtectedRoutes(r, controllers, authMiddleware, rateLimiter)
+	setupMiroAuthRoutes(r, miroAuthMiddleware, rateLimiter)
 	setupFileStoreRoutes(r)
+	setupMetricsRoutes(r, logger)
+	setupHealthRoutes(r, controllers)
 }
 
 // setupGlobalMiddleware configures global middleware for all routes
 func setupGlobalMiddleware(r *Router, logger service.Logger) {
-	// Add cancellation middleware first to handle client disconnections
-	cancellationMiddleware := middleware.NewCancellationMiddleware(logger)
+	// Record metrics first so that every response is observed
+	if r.Metrics != nil {
+		metricsMiddleware := middleware.NewMetricsMiddleware(r.Metrics)
+		r.Echo.Use(metricsMiddleware.Observe)
+	}
+
+	// Start a span for every request, continuing the trace of the caller
+	if r.Config.Tracing.Enabled {
+		r.Echo.Use(otelecho.Middleware(
+			r.Config.Logger.ServiceName,
+			otelecho.WithTracerProvider(r.Tracer),
+			otelecho.WithSkipper(func(c echo.Context) bool {
+				return strings.HasPrefix(c.Path(), "/health") || c.Path() == r.Config.Metrics.Path
+			}),
+		))
+	}
+
+	// Add cancellation middleware to handle client disconnections. Downloads
+	// and saves streamed between Document Servers and Miro are bounded by
+	// their own handler deadlines.
+	cancellationMiddleware := middleware.NewCancellationMiddleware(logger, "/api/files/download", "/api/callback")
 	r.Echo.Use(cancellationMiddleware.HandleRequestCancellation)
 
 	// Basic panic recovery middleware
@@ -72,35 +100,23 @@ func setupGlobalMiddleware(r *Router, logger service.Logger) {
 		AllowCredentials: r.Config.CORS.AllowCredentials,
 		MaxAge:           r.Config.CORS.MaxAge,
 	}))
+}
 
-	// Rate limiting
-	store, err := middleware.NewRedisStore(r.Config, logger)
-	if err != nil {
-		log.Fatalf("failed to initialize Redis store: %v", err)
-		return
+// setupRateLimitMiddleware creates the rate limiter shared by all route groups.
+// Buckets live in Redis when available and fall back to the local store while
+// Redis is unhealthy.
+func setupRateLimitMiddleware(r *Router, logger service.Logger) *middleware.RateLimitMiddleware {
+	var store middleware.RateLimiter = middleware.NewMemoryStore(r.Config, logger)
+	if r.Redis != nil {
+		store = middleware.NewFallbackStore(
+			middleware.NewRedisStore(r.Redis, logger),
+			store,
+			r.RedisBreaker,
+			logger,
+		)
 	}
 
-	r.Echo.Use(echomiddleware.RateLimiterWithConfig(echomiddleware.RateLimiterConfig{
-		Skipper: echomiddleware.DefaultSkipper,
-		Store:   store,
-		IdentifierExtractor: func(ctx echo.Context) (string, error) {
-			return ctx.RealIP(), nil
-		},
-		ErrorHandler: func(context echo.Context, err error) error {
-			return &echo.HTTPError{
-				Code:     echomiddleware.ErrRateLimitExceeded.Code,
-				Message:  echomiddleware.ErrRateLimitExceeded.Message,
-				Internal: err,
-			}
-		},
-		DenyHandler: func(context echo.Context, identifier string, err error) error {
-			return &echo.HTTPError{
-				Code:     echomiddleware.ErrRateLimitExceeded.Code,
-				Message:  echomiddleware.ErrRateLimitExceeded.Message,
-				Internal: err,
-			}
-		},
-	}))
+	return middleware.NewRateLimitMiddleware(store, r.Config.RateLimit, r.Metrics, logger)
 }
 
 func setupErrorHandler(r *Router, logger service.Logger) {
@@ -162,33 +178,86 @@ func setupAuthMiddleware(r *Router, logger service.Logger) (
 }
 
 // setupEditorRoutes configures editor-related routes
-func setupEditorRoutes(r *Router, controllers *Controllers, editorMiddleware *authentication.AuthMiddleware) {
+func setupEditorRoutes(
+	r *Router,
+	controllers *Controllers,
+	editorMiddleware *authentication.AuthMiddleware,
+	rateLimiter *middleware.RateLimitMiddleware,
+) {
 	handlers := controllers.Editor.Handlers()
-	r.Echo.GET("/editor", editorMiddleware.Authenticate(handlers[common.MethodGet]))
+	limit := rateLimiter.Limit(config.RateLimitPolicyEditor)
+	r.Echo.GET("/editor", editorMiddleware.Authenticate(limit(handlers[common.MethodGet])))
 }
 
-// setupCallbackRoutes configures callback-related routes
+// setupCallbackRoutes configures callback-related routes.
+// Document Server callbacks are never rate limited.
 func setupCallbackRoutes(r *Router, controllers *Controllers) {
 	handlers := controllers.Callback.Handlers()
 	r.Echo.POST("/api/callback", handlers[common.MethodPost])
 }
 
+// setupDownloadRoutes configures file download routes used by Document Servers.
+// Like callbacks they are not rate limited: every editor session on a shared
+// Document Server arrives from the same address, and requests are already
+// authorized by a signed token.
+func setupDownloadRoutes(r *Router, controllers *Controllers) {
+	handlers := controllers.FileDownload.Handlers()
+	r.Echo.GET("/api/files/download", handlers[common.MethodGet])
+}
+
 // setupAuthRoutes configures authentication-related routes
-func setupAuthRoutes(r *Router, controllers *Controllers) {
+func setupAuthRoutes(r *Router, controllers *Controllers, rateLimiter *middleware.RateLimitMiddleware) {
+	limit := rateLimiter.Limit(config.RateLimitPolicyOAuth)
+
 	handlers := controllers.Auth.Handlers()
-	r.Echo.GET("/api/oauth", handlers[common.MethodGet])
+	r.Echo.GET("/api/oauth", handlers[common.MethodGet], limit)
+
+	handlers = controllers.Install.Handlers()
+	r.Echo.GET("/api/oauth/install", handlers[common.MethodGet], limit)
+
+	handlers = controllers.Uninstall.Handlers()
+	r.Echo.POST("/api/webhooks/uninstall", handlers[common.MethodPost], limit)
+}
+
+// setupAdminRoutes configures operator routes guarded by the admin token.
+// They are not registered at all unless an admin token is configured.
+func setupAdminRoutes(
+	r *Router,
+	controllers *Controllers,
+	rateLimiter *middleware.RateLimitMiddleware,
+	logger service.Logger,
+) {
+	if !r.Config.Admin.Enabled() {
+		return
+	}
+
+	adminMiddleware := middleware.NewAdminMiddleware(r.Config.Admin, logger)
+	limit := rateLimiter.Limit(config.RateLimitPolicyDefault)
+	handlers := controllers.Admin.Handlers()
+	r.Echo.GET("/api/admin/tokens", adminMiddleware.Authenticate(handlers[common.MethodGet]), limit)
+	r.Echo.GET("/api/admin/cache", adminMiddleware.Authenticate(controllers.CacheStats.Handlers()[common.MethodGet]), limit)
+	r.Echo.GET("/api/admin/breakers", adminMiddleware.Authenticate(controllers.Breakers.Handlers()[common.MethodGet]), limit)
 }
 
 // setupProtectedRoutes configures routes that require authentication
-func setupProtectedRoutes(r *Router, controllers *Controllers, authMiddleware *authentication.AuthMiddleware) {
+func setupProtectedRoutes(
+	r *Router,
+	controllers *Controllers,
+	authMiddleware *authentication.AuthMiddleware,
+	rateLimiter *middleware.RateLimitMiddleware,
+) {
 	protected := r.Echo.Group("/api")
-	protected.Use(authMiddleware.Authenticate)
+	protected.Use(authMiddleware.Authenticate, rateLimiter.Limit(config.RateLimitPolicyAPI))
 
 	// Settings routes
 	handlers := controllers.Settings.Handlers()
 	protected.GET("/settings", handlers[common.MethodGet])
 	protected.POST("/settings", handlers[common.MethodPost])
 
+	// Authorization routes
+	handlers = controllers.Disconnect.Handlers()
+	protected.POST("/oauth/disconnect", handlers[common.MethodPost])
+
 	// File management routes
 	handlers = controllers.FileManagement.Handlers()
 	protected.GET("/files", handlers[common.MethodGet])
@@ -200,16 +269,17 @@ func setupProtectedRoutes(r *Router, controllers *Controllers, authMiddleware *a
 }
 
 // setupMiroAuthRoutes configures Miro-specific authentication routes
-func setupMiroAuthRoutes(r *Router, miroAuthMiddleware *authentication.AuthMiddleware) {
-	r.Echo.GET("/api/authorize", miroAuthMiddleware.Authenticate(miroAuthMiddleware.GetTokenAuthorization))
+func setupMiroAuthRoutes(
+	r *Router,
+	miroAuthMiddleware *authentication.AuthMiddleware,
+	rateLimiter *middleware.RateLimitMiddleware,
+) {
+	limit := rateLimiter.Limit(config.RateLimitPolicyAPI)
+	r.Echo.GET("/api/authorize", miroAuthMiddleware.Authenticate(limit(miroAuthMiddleware.GetTokenAuthorization)))
 }
 
 // setupFileStoreRoutes configures file store routes to serve embedded assets
 func setupFileStoreRoutes(r *Router) {
-	r.Echo.GET("/health", func(c echo.Context) error {
-		return c.JSON(http.StatusOK, map[string]string{"status": "UP"})
-	})
-
 	r.Echo.GET("/filestore/*", func(c echo.Context) error {
 		reqPath := c.Param("*")
 		if strings.HasPrefix(reqPath, "icons/") {
@@ -229,3 +299,30 @@ func setupFileStoreRoutes(r *Router) {
 		return c.NoContent(http.StatusNotFound)
 	})
 }
+
+// setupMetricsRoutes exposes the Prometheus metrics endpoint behind the admin
+// token, so it is only served when the admin API is enabled
+func setupMetricsRoutes(r *Router, logger service.Logger) {
+	if r.Metrics == nil {
+		return
+	}
+
+	if !r.Config.Admin.Enabled() {
+		logger.Warn(context.Background(), "Metrics endpoint disabled because no admin token is configured", service.Fields{
+			"path": r.Config.Metrics.Path,
+		})
+		return
+	}
+
+	adminMiddleware := middleware.NewAdminMiddleware(r.Config.Admin, logger)
+	r.Echo.GET(r.Config.Metrics.Path, adminMiddleware.Authenticate(echo.WrapHandler(r.Metrics.Handler())))
+}
+
+// setupHealthRoutes configures the liveness and readiness probes
+func setupHealthRoutes(r *Router, controllers *Controllers) {
+	live := controllers.Liveness.Handlers()[common.MethodGet]
+
+	r.Echo.GET("/health", live)
+	r.Echo.GET("/health/live", live)
+	r.Echo.GET("/health/ready", controllers.Readiness.Handlers()[common.MethodGet])
+}
@@ -17,10 +17,25 @@
  */
 package component
 
+import "time"
+
+type AuthenticationStatus string
+
+const (
+	// AuthenticationStatusActive marks tokens that can still be refreshed.
+	AuthenticationStatusActive AuthenticationStatus = "active"
+	// AuthenticationStatusRejected marks tokens whose refresh token was
+	// rejected by the authorization server and require a new installation.
+	AuthenticationStatusRejected AuthenticationStatus = "rejected"
+)
+
 type Authentication struct {
-	TokenType    string `json:"token_type"`
-	AccessToken  string `json:"access_token"`
-	RefreshToken string `json:"refresh_token"`
-	ExpiresAt    int    `json:"expires_at"`
-	Scope        string `json:"scope"`
+	TokenType       string               `json:"token_type"`
+	AccessToken     string               `json:"access_token"`
+	RefreshToken    string               `json:"refresh_token"`
+	ExpiresAt       int                  `json:"expires_at"`
+	Scope           string               `json:"scope"`
+	Status          AuthenticationStatus `json:"status,omitempty"`
+	RefreshedAt     *time.Time           `json:"refreshed_at,omitempty"`
+	RefreshFailures int                  `json:"refresh_failures,omitempty"`
 }
@@ -17,10 +17,27 @@
  */
 package component
 
+import "time"
+
+type UploadMode string
+
+const (
+	// UploadModeURL lets Miro fetch saved documents from the Document Server.
+	UploadModeURL UploadMode = "url"
+	// UploadModeContent streams saved documents to Miro through the backend.
+	UploadModeContent UploadMode = "content"
+)
+
 type Settings struct {
-	Address      string `json:"address"`
-	Header       string `json:"header"`
-	Secret       string `json:"secret"`
-	Demo         Demo   `json:"demo,omitempty"`
-	DemoDetached bool   `json:"demo_detached"`
+	Address            string     `json:"address"`
+	InternalAddress    string     `json:"internal_address,omitempty"`
+	Header             string     `json:"header"`
+	Secret             string     `json:"secret"`
+	SecondarySecret    string     `json:"secondary_secret,omitempty"`
+	SecondaryExpiresAt *time.Time `json:"secondary_expires_at,omitempty"`
+	Algorithm          string     `json:"algorithm,omitempty"`
+	UploadMode         UploadMode `json:"upload_mode,omitempty"`
+	TLS                TLS        `json:"tls"`
+	Demo               Demo       `json:"demo,omitempty"`
+	DemoDetached       bool       `json:"demo_detached"`
 }
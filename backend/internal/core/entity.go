@@ -24,7 +24,47 @@ type AuthCompositeKey struct {
 	UserID string
 }
 
+// AuthFilter narrows down authentication listings. Zero values are ignored.
+// Listings are ordered by expiry, then by key, so that After can page
+// through them.
+type AuthFilter struct {
+	TeamID        string
+	ExpiresBefore int
+	Status        string
+	Limit         int
+	After         *AuthCursor
+}
+
+// AuthCursor marks the last record of a listing page.
+type AuthCursor struct {
+	ExpiresAt int
+	Key       AuthCompositeKey
+}
+
+// Before reports whether the cursor sorts before the given record.
+func (c AuthCursor) Before(expiresAt int, key AuthCompositeKey) bool {
+	if c.ExpiresAt != expiresAt {
+		return c.ExpiresAt < expiresAt
+	}
+
+	if c.Key.TeamID != key.TeamID {
+		return c.Key.TeamID < key.TeamID
+	}
+
+	return c.Key.UserID < key.UserID
+}
+
 type SettingsCompositeKey struct {
 	TeamID  string
 	BoardID string
 }
+
+// SettingsFilter narrows down settings listings. Zero values are ignored.
+type SettingsFilter struct {
+	TeamID string
+}
+
+type DocumentKeyCompositeKey struct {
+	BoardID string
+	FileID  string
+}
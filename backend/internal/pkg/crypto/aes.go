@@ -24,6 +24,7 @@ import (
 	"crypto/sha256"
 	"encoding/base64"
 	"io"
+	"sync"
 
 	pbkdf2 "golang.org/x/crypto/pbkdf2"
 )
@@ -32,14 +33,48 @@ const (
 	pbkdf2Iterations = 4096
 	saltSize         = 16
 	keySize          = 32
+	derivedKeyLimit  = 4096
 )
 
 type aesCipher struct {
 	key []byte
+
+	mu          sync.RWMutex
+	derivedKeys map[string][]byte
 }
 
 func NewAESCipher(key []byte) Cipher {
-	return &aesCipher{key: key}
+	return &aesCipher{
+		key:         key,
+		derivedKeys: make(map[string][]byte),
+	}
+}
+
+// deriveKey returns the PBKDF2 key for salt. Every ciphertext carries its own
+// salt, so keys are memoized per salt to avoid re-deriving them each time the
+// same value is decrypted. Once the limit is reached an arbitrary entry is
+// evicted.
+func (p *aesCipher) deriveKey(salt []byte) []byte {
+	p.mu.RLock()
+	key, ok := p.derivedKeys[string(salt)]
+	p.mu.RUnlock()
+	if ok {
+		return key
+	}
+
+	key = pbkdf2.Key(p.key, salt, pbkdf2Iterations, keySize, sha256.New)
+
+	p.mu.Lock()
+	if len(p.derivedKeys) >= derivedKeyLimit {
+		for k := range p.derivedKeys {
+			delete(p.derivedKeys, k)
+			break
+		}
+	}
+	p.derivedKeys[string(salt)] = key
+	p.mu.Unlock()
+
+	return key
 }
 
 func generateRandomSalt(length int) ([]byte, error) {
@@ -61,7 +96,7 @@ func (p *aesCipher) Encrypt(plaintext string) (string, error) {
 		return "", err
 	}
 
-	key := pbkdf2.Key(p.key, salt, pbkdf2Iterations, keySize, sha256.New)
+	key := p.deriveKey(salt)
 	block, err := aes.NewCipher(key)
 	if err != nil {
 		return "", err
@@ -98,7 +133,7 @@ func (p *aesCipher) Decrypt(ciphertext string) (string, error) {
 
 	salt := ciphertextBytes[:saltSize]
 	encrypted := ciphertextBytes[saltSize:]
-	key := pbkdf2.Key(p.key, salt, pbkdf2Iterations, keySize, sha256.New)
+	key := p.deriveKey(salt)
 	block, err := aes.NewCipher(key)
 	if err != nil {
 		return "", err
@@ -122,3 +157,8 @@ func (p *aesCipher) Decrypt(ciphertext string) (string, error) {
 
 	return string(plaintext), nil
 }
+
+// NeedsReencryption always returns false as a single key cannot be rotated.
+func (p *aesCipher) NeedsReencryption(ciphertext string) bool {
+	return false
+}
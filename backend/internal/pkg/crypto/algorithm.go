@@ -0,0 +1,50 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package crypto
+
+import jwt "github.com/golang-jwt/jwt/v5"
+
+type Algorithm string
+
+const (
+	HS256 Algorithm = "HS256"
+	HS384 Algorithm = "HS384"
+	HS512 Algorithm = "HS512"
+)
+
+func (a Algorithm) IsValid() bool {
+	switch a {
+	case "", HS256, HS384, HS512:
+		return true
+	default:
+		return false
+	}
+}
+
+// SigningMethod returns the HMAC signing method for the algorithm, falling
+// back to HS256 when the algorithm is empty or unknown.
+func (a Algorithm) SigningMethod() *jwt.SigningMethodHMAC {
+	switch a {
+	case HS384:
+		return jwt.SigningMethodHS384
+	case HS512:
+		return jwt.SigningMethodHS512
+	default:
+		return jwt.SigningMethodHS256
+	}
+}
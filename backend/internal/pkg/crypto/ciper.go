@@ -20,4 +20,7 @@ package crypto
 type Cipher interface {
 	Encrypt(plaintext string) (string, error)
 	Decrypt(ciphertext string) (string, error)
+	// NeedsReencryption reports whether ciphertext was produced with a key
+	// other than the one currently used for encryption.
+	NeedsReencryption(ciphertext string) bool
 }
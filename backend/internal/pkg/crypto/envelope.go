@@ -0,0 +1,192 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package crypto
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	envelopePrefix = "env:"
+	// dataKeyLifetime bounds how long a data key encrypts new values before
+	// a fresh one is generated and wrapped.
+	dataKeyLifetime = time.Hour
+	// dataKeyLimit bounds the number of unwrapped data keys kept in memory.
+	dataKeyLimit = 1024
+)
+
+type dataKey struct {
+	wrapped string
+	cipher  Cipher
+	created time.Time
+}
+
+type envelopeCipher struct {
+	manager  KeyManager
+	fallback Cipher
+
+	mu       sync.RWMutex
+	current  *dataKey
+	dataKeys map[string]Cipher
+}
+
+// NewEnvelopeCipher returns a Cipher that encrypts values with AES-GCM data
+// keys wrapped by manager. The wrapped data key is stored next to every
+// ciphertext. Values that are not in the envelope format are decrypted with
+// fallback, which lets secrets written before envelope encryption was
+// enabled be read and re-encrypted.
+func NewEnvelopeCipher(manager KeyManager, fallback Cipher) Cipher {
+	return &envelopeCipher{
+		manager:  manager,
+		fallback: fallback,
+		dataKeys: make(map[string]Cipher),
+	}
+}
+
+// currentKey returns the data key used for encryption, generating and
+// wrapping a new one once the previous key has been used for too long.
+func (e *envelopeCipher) currentKey() (*dataKey, error) {
+	e.mu.RLock()
+	current := e.current
+	e.mu.RUnlock()
+	if current != nil && time.Since(current.created) < dataKeyLifetime {
+		return current, nil
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.current != nil && time.Since(e.current.created) < dataKeyLifetime {
+		return e.current, nil
+	}
+
+	key := make([]byte, keySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, err
+	}
+
+	wrapped, err := e.manager.WrapKey(context.Background(), key)
+	if err != nil {
+		return nil, err
+	}
+
+	e.current = &dataKey{
+		wrapped: base64.RawURLEncoding.EncodeToString([]byte(wrapped)),
+		cipher:  NewAESCipher(key),
+		created: time.Now(),
+	}
+	e.storeKey(e.current.wrapped, e.current.cipher)
+
+	return e.current, nil
+}
+
+// storeKey memoizes an unwrapped data key. The caller must hold the lock.
+func (e *envelopeCipher) storeKey(wrapped string, cipher Cipher) {
+	if len(e.dataKeys) >= dataKeyLimit {
+		for k := range e.dataKeys {
+			delete(e.dataKeys, k)
+			break
+		}
+	}
+
+	e.dataKeys[wrapped] = cipher
+}
+
+// unwrapKey returns the cipher of a stored data key, asking the key manager
+// to unwrap it only when it is not memoized yet.
+func (e *envelopeCipher) unwrapKey(wrapped string) (Cipher, error) {
+	e.mu.RLock()
+	cipher, ok := e.dataKeys[wrapped]
+	e.mu.RUnlock()
+	if ok {
+		return cipher, nil
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(wrapped)
+	if err != nil {
+		return nil, ErrMalformedEnvelope
+	}
+
+	key, err := e.manager.UnwrapKey(context.Background(), string(decoded))
+	if err != nil {
+		return nil, err
+	}
+
+	cipher = NewAESCipher(key)
+
+	e.mu.Lock()
+	e.storeKey(wrapped, cipher)
+	e.mu.Unlock()
+
+	return cipher, nil
+}
+
+func (e *envelopeCipher) Encrypt(plaintext string) (string, error) {
+	if plaintext == "" {
+		return "", ErrCipherTextEmpty
+	}
+
+	key, err := e.currentKey()
+	if err != nil {
+		return "", err
+	}
+
+	ciphertext, err := key.cipher.Encrypt(plaintext)
+	if err != nil {
+		return "", err
+	}
+
+	return envelopePrefix + key.wrapped + keyIDSeparator + ciphertext, nil
+}
+
+func (e *envelopeCipher) Decrypt(ciphertext string) (string, error) {
+	if ciphertext == "" {
+		return "", ErrCipherTextEmpty
+	}
+
+	envelope, ok := strings.CutPrefix(ciphertext, envelopePrefix)
+	if !ok {
+		if e.fallback == nil {
+			return "", ErrUnknownKey
+		}
+
+		return e.fallback.Decrypt(ciphertext)
+	}
+
+	wrapped, payload, ok := strings.Cut(envelope, keyIDSeparator)
+	if !ok {
+		return "", ErrMalformedEnvelope
+	}
+
+	cipher, err := e.unwrapKey(wrapped)
+	if err != nil {
+		return "", err
+	}
+
+	return cipher.Decrypt(payload)
+}
+
+func (e *envelopeCipher) NeedsReencryption(ciphertext string) bool {
+	return ciphertext != "" && !strings.HasPrefix(ciphertext, envelopePrefix)
+}
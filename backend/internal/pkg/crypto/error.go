@@ -22,6 +22,8 @@ import "errors"
 var (
 	ErrCipherTextEmpty    = errors.New("cipher text is empty")
 	ErrCipherTextTooShort = errors.New("cipher text too short")
+	ErrUnknownKey         = errors.New("cipher text was encrypted with an unknown key")
+	ErrMalformedEnvelope  = errors.New("malformed envelope cipher text")
 	ErrInvalidToken       = errors.New("invalid token")
 	ErrInvalidTokenClaims = errors.New("invalid token claims")
 	ErrTokenMapping       = errors.New("failed to map token")
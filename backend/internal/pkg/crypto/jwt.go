@@ -29,10 +29,21 @@ func NewJwtService() Signer {
 	return &jwtService{}
 }
 
-func (s *jwtService) Validate(tokenString string, secret []byte) (*jwt.Token, error) {
+// Validate accepts only tokens signed with the configured algorithm, or with
+// HS256 when none is configured, so that a token cannot pick a weaker one.
+func (s *jwtService) Validate(tokenString string, secret []byte, algorithm Algorithm, fallbacks ...[]byte) (*jwt.Token, error) {
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (any, error) {
-		return secret, nil
-	})
+		if len(fallbacks) == 0 {
+			return secret, nil
+		}
+
+		keys := jwt.VerificationKeySet{Keys: []jwt.VerificationKey{secret}}
+		for _, fallback := range fallbacks {
+			keys.Keys = append(keys.Keys, fallback)
+		}
+
+		return keys, nil
+	}, jwt.WithValidMethods([]string{algorithm.SigningMethod().Alg()}))
 
 	if err != nil {
 		return nil, err
@@ -45,8 +56,8 @@ func (s *jwtService) Validate(tokenString string, secret []byte) (*jwt.Token, er
 	return token, nil
 }
 
-func (s *jwtService) ValidateTarget(tokenString string, secret []byte, target any) error {
-	token, err := s.Validate(tokenString, secret)
+func (s *jwtService) ValidateTarget(tokenString string, secret []byte, algorithm Algorithm, target any, fallbacks ...[]byte) error {
+	token, err := s.Validate(tokenString, secret, algorithm, fallbacks...)
 	if err != nil {
 		return err
 	}
@@ -69,6 +80,10 @@ func (s *jwtService) ValidateTarget(tokenString string, secret []byte, target an
 }
 
 func (s *jwtService) Create(claims jwt.Claims, secret []byte) (string, error) {
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return s.CreateWithAlgorithm(claims, secret, HS256)
+}
+
+func (s *jwtService) CreateWithAlgorithm(claims jwt.Claims, secret []byte, algorithm Algorithm) (string, error) {
+	token := jwt.NewWithClaims(algorithm.SigningMethod(), claims)
 	return token.SignedString(secret)
 }
@@ -0,0 +1,104 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package crypto
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+const keyIDSeparator = ":"
+
+type keyring struct {
+	currentID string
+	ids       []string
+	ciphers   map[string]Cipher
+}
+
+// KeyID returns the identifier a keyring stores in front of ciphertexts
+// produced with secret. It is a short fingerprint, not the secret itself.
+func KeyID(secret []byte) string {
+	sum := sha256.Sum256(secret)
+	return hex.EncodeToString(sum[:4])
+}
+
+// NewKeyring returns a Cipher that encrypts with current and decrypts with
+// current or any of the previous secrets. Ciphertexts are prefixed with the
+// identifier of their key; unprefixed ciphertexts written before keyrings
+// were introduced are tried against every known key.
+func NewKeyring(current []byte, previous ...[]byte) Cipher {
+	k := &keyring{
+		currentID: KeyID(current),
+		ciphers:   make(map[string]Cipher, len(previous)+1),
+	}
+
+	for _, secret := range append([][]byte{current}, previous...) {
+		if len(secret) == 0 {
+			continue
+		}
+
+		id := KeyID(secret)
+		if _, ok := k.ciphers[id]; ok {
+			continue
+		}
+
+		k.ids = append(k.ids, id)
+		k.ciphers[id] = NewAESCipher(secret)
+	}
+
+	return k
+}
+
+func (k *keyring) Encrypt(plaintext string) (string, error) {
+	ciphertext, err := k.ciphers[k.currentID].Encrypt(plaintext)
+	if err != nil {
+		return "", err
+	}
+
+	return k.currentID + keyIDSeparator + ciphertext, nil
+}
+
+func (k *keyring) Decrypt(ciphertext string) (string, error) {
+	if ciphertext == "" {
+		return "", ErrCipherTextEmpty
+	}
+
+	if id, payload, ok := strings.Cut(ciphertext, keyIDSeparator); ok {
+		cipher, ok := k.ciphers[id]
+		if !ok {
+			return "", ErrUnknownKey
+		}
+
+		return cipher.Decrypt(payload)
+	}
+
+	var err error
+	for _, id := range k.ids {
+		var plaintext string
+		if plaintext, err = k.ciphers[id].Decrypt(ciphertext); err == nil {
+			return plaintext, nil
+		}
+	}
+
+	return "", err
+}
+
+func (k *keyring) NeedsReencryption(ciphertext string) bool {
+	return ciphertext != "" && !strings.HasPrefix(ciphertext, k.currentID+keyIDSeparator)
+}
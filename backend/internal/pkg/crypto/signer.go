@@ -20,7 +20,8 @@ package crypto
 import jwt "github.com/golang-jwt/jwt/v5"
 
 type Signer interface {
-	Validate(tokenString string, secret []byte) (*jwt.Token, error)
-	ValidateTarget(tokenString string, secret []byte, target any) error
+	Validate(tokenString string, secret []byte, algorithm Algorithm, fallbacks ...[]byte) (*jwt.Token, error)
+	ValidateTarget(tokenString string, secret []byte, algorithm Algorithm, target any, fallbacks ...[]byte) error
 	Create(claims jwt.Claims, secret []byte) (string, error)
+	CreateWithAlgorithm(claims jwt.Claims, secret []byte, algorithm Algorithm) (string, error)
 }
@@ -26,4 +26,7 @@ type Cache interface {
 	Get(ctx context.Context, key string) ([]byte, error)
 	Set(ctx context.Context, key string, value []byte, expiration time.Duration) error
 	Delete(ctx context.Context, key string) error
+	// GetDel returns the value of key and removes it atomically, so that a
+	// value can be taken by at most one caller. A missing key yields nil.
+	GetDel(ctx context.Context, key string) ([]byte, error)
 }
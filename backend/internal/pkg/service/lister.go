@@ -0,0 +1,31 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package service
+
+import "context"
+
+// Record pairs a stored value with the key it is stored under.
+type Record[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+// Lister returns every record matching the given filter.
+type Lister[K comparable, V any, F any] interface {
+	List(ctx context.Context, filter F) ([]Record[K, V], error)
+}
@@ -24,3 +24,7 @@ type StorageProcessor[ID comparable, T any, R any] interface {
 	BuildUpdateQuery(id ID, component T) (query string, args []any)
 	BuildDeleteQuery(id ID) (query string, args []any)
 }
+
+type ListProcessor[K comparable, V any, F any, R any] interface {
+	BuildListQuery(filter F) (query string, args []any, scanner func(R) (K, V, error))
+}
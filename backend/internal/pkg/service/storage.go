@@ -17,7 +17,13 @@
  */
 package service
 
-import "context"
+import (
+	"context"
+	"errors"
+)
+
+// ErrNoRowsAffected is returned by storages when the record does not exist.
+var ErrNoRowsAffected = errors.New("no rows have been affected")
 
 type Storage[K comparable, V any] interface {
 	Find(context.Context, K) (V, error)
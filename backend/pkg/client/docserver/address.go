@@ -0,0 +1,61 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package docserver
+
+import (
+	"net/url"
+	"strings"
+)
+
+// RewriteAddress replaces the from address prefix of a Document Server URL
+// with the to address. URLs that do not start with from are returned as is.
+func RewriteAddress(rawURL, from, to string) string {
+	from = strings.TrimRight(from, "/")
+	to = strings.TrimRight(to, "/")
+	if rawURL == "" || from == "" || to == "" || from == to {
+		return rawURL
+	}
+
+	source, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+
+	prefix, err := url.Parse(from)
+	if err != nil || !strings.EqualFold(source.Host, prefix.Host) || source.Scheme != prefix.Scheme {
+		return rawURL
+	}
+
+	// The prefix must end on a path segment boundary, so that an address of
+	// /ds does not match /dsx.
+	base := strings.TrimSuffix(prefix.Path, "/")
+	if source.Path != base && !strings.HasPrefix(source.Path, base+"/") {
+		return rawURL
+	}
+
+	target, err := url.Parse(to)
+	if err != nil {
+		return rawURL
+	}
+
+	source.Scheme = target.Scheme
+	source.Host = target.Host
+	source.Path = target.Path + strings.TrimPrefix(source.Path, base)
+	source.RawPath = ""
+	return source.String()
+}
@@ -30,29 +30,52 @@ import (
 
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/service"
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/common"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/breaker"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/metrics"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/tracing"
+	"go.opentelemetry.io/otel"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/client/docserver")
+
+// Operations label the calls made to the Document Server in metrics and traces.
+const (
+	operationGetServerVersion = "get_server_version"
+	operationConvertFile      = "convert_file"
+	operationDownloadFile     = "download_file"
 )
 
 type client struct {
 	httpClient *http.Client
+	transports *transportCache
+	metrics    *metrics.Metrics
 	logger     service.Logger
 }
 
-func NewClient(logger service.Logger) Client {
+// NewClient creates a Document Server client. Requests to each server are
+// guarded by the circuit breaker and bulkhead taken from guards, when provided.
+func NewClient(guards *breaker.Registry, metrics *metrics.Metrics, logger service.Logger) Client {
 	return &client{
 		httpClient: &http.Client{
-			Timeout: 3 * time.Second,
-			Transport: common.NewRetryableTransport(&http.Transport{
-				MaxIdleConnsPerHost:    100,
-				IdleConnTimeout:        90 * time.Second,
-				MaxResponseHeaderBytes: 1 << 20,
-				DisableCompression:     false,
-				ForceAttemptHTTP2:      true,
-			}),
+			Timeout:   3 * time.Second,
+			Transport: breaker.NewTransport(common.NewRetryableTransport(newTransport()), guards),
 		},
-		logger: logger,
+		transports: newTransportCache(3*time.Second, guards),
+		metrics:    metrics,
+		logger:     logger,
 	}
 }
 
+func (c *client) clientFor(options *ClientOptions) (*http.Client, error) {
+	if options.TLS.IsEmpty() {
+		return c.httpClient, nil
+	}
+
+	return c.transports.Get(options.TLS)
+}
+
 func (c *client) createRequest(ctx context.Context, method, baseURL, path string, body any, options *ClientOptions) (*http.Request, error) {
 	c.logger.Debug(ctx, "Creating DocServer request", service.Fields{
 		"method":  method,
@@ -108,14 +131,37 @@ func (c *client) createRequest(ctx context.Context, method, baseURL, path string
 	return req, nil
 }
 
-func (c *client) sendRequest(req *http.Request, target any) error {
+func (c *client) sendRequest(operation string, req *http.Request, options *ClientOptions, target any) error {
+	ctx, span := tracer.Start(req.Context(), "docserver."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.HTTPRequestMethodKey.String(req.Method)),
+	)
+
+	start := time.Now()
+	err := c.exchange(req.WithContext(ctx), options, target)
+	c.metrics.ObserveUpstream(metrics.ClientDocServer, operation, time.Since(start), err)
+
+	tracing.End(span, err)
+	return err
+}
+
+// exchange sends a single request to the Document Server and decodes its response.
+func (c *client) exchange(req *http.Request, options *ClientOptions, target any) error {
 	ctx := req.Context()
 	c.logger.Debug(ctx, "Sending DocServer request", service.Fields{
 		"method": req.Method,
 		"url":    req.URL.String(),
 	})
 
-	resp, err := c.httpClient.Do(req)
+	httpClient, err := c.clientFor(options)
+	if err != nil {
+		c.logger.Error(ctx, "Invalid DocServer TLS configuration", service.Fields{
+			"error": err.Error(),
+		})
+		return fmt.Errorf("invalid tls configuration: %w", err)
+	}
+
+	resp, err := httpClient.Do(req)
 	if err != nil {
 		c.logger.Error(ctx, "Failed to send DocServer request", service.Fields{
 			"method": req.Method,
@@ -126,6 +172,7 @@ func (c *client) sendRequest(req *http.Request, target any) error {
 	}
 
 	defer resp.Body.Close()
+	trace.SpanFromContext(ctx).SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
 	if resp.StatusCode != http.StatusOK {
 		c.logger.Error(ctx, "Received non-OK status code from DocServer", service.Fields{
 			"method":     req.Method,
@@ -171,7 +218,7 @@ func (c *client) GetServerVersion(ctx context.Context, base string, opts ...Opti
 	}
 
 	var response ServerVersionResponse
-	if err := c.sendRequest(req, &response); err != nil {
+	if err := c.sendRequest(operationGetServerVersion, req, options, &response); err != nil {
 		c.logger.Error(ctx, "Failed to get server version", service.Fields{
 			"baseURL": base,
 			"error":   err.Error(),
@@ -206,7 +253,7 @@ func (c *client) ConvertFile(ctx context.Context, base, token string, opts ...Op
 	}
 
 	var response FileConversionResponse
-	if err := c.sendRequest(req, &response); err != nil {
+	if err := c.sendRequest(operationConvertFile, req, options, &response); err != nil {
 		c.logger.Error(ctx, "Failed to convert file", service.Fields{
 			"baseURL": base,
 			"error":   err.Error(),
@@ -227,5 +274,75 @@ func (c *client) ConvertFile(ctx context.Context, base, token string, opts ...Op
 		return nil, fmt.Errorf("failed to convert file: %d", response.Error)
 	}
 
+	response.FileURL = RewriteAddress(response.FileURL, base, options.PublicAddress)
 	return &response, nil
 }
+
+func (c *client) DownloadFile(ctx context.Context, fileURL string, opts ...Option) (*FileDownloadResponse, error) {
+	c.logger.Info(ctx, "Downloading DocServer file", service.Fields{
+		"url": fileURL,
+	})
+
+	options := DefaultClientOptions()
+	ApplyOptions(options, opts...)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fileURL, nil)
+	if err != nil {
+		c.logger.Error(ctx, "Failed to create DocServer download request", service.Fields{
+			"url":   fileURL,
+			"error": err.Error(),
+		})
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpClient, err := c.clientFor(options)
+	if err != nil {
+		c.logger.Error(ctx, "Invalid DocServer TLS configuration", service.Fields{
+			"error": err.Error(),
+		})
+		return nil, fmt.Errorf("invalid tls configuration: %w", err)
+	}
+
+	// Downloads are streamed, so they are bound by the request context
+	// rather than by the client timeout.
+	_, span := tracer.Start(ctx, "docserver."+operationDownloadFile,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.HTTPRequestMethodKey.String(http.MethodGet)),
+	)
+
+	start := time.Now()
+	observe := func(err error) {
+		c.metrics.ObserveUpstream(metrics.ClientDocServer, operationDownloadFile, time.Since(start), err)
+		tracing.End(span, err)
+	}
+
+	resp, err := (&http.Client{Transport: httpClient.Transport}).Do(req)
+	if err != nil {
+		observe(err)
+		c.logger.Error(ctx, "Failed to download DocServer file", service.Fields{
+			"url":   fileURL,
+			"error": err.Error(),
+		})
+		return nil, err
+	}
+
+	span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		err := fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		observe(err)
+		c.logger.Error(ctx, "Received non-OK status code from DocServer", service.Fields{
+			"url":        fileURL,
+			"statusCode": resp.StatusCode,
+		})
+		return nil, err
+	}
+
+	// Only the time to the first byte is observed, the body is streamed by the caller.
+	observe(nil)
+
+	return &FileDownloadResponse{
+		Body:          resp.Body,
+		ContentLength: resp.ContentLength,
+	}, nil
+}
@@ -20,5 +20,9 @@ package docserver
 import "errors"
 
 var (
-	ErrTokenRequired = errors.New("token is required")
+	ErrTokenRequired            = errors.New("token is required")
+	ErrInvalidCABundle          = errors.New("ca bundle does not contain any valid PEM certificate")
+	ErrInvalidClientCertificate = errors.New("invalid client certificate or key")
+	ErrInvalidPinnedCertificate = errors.New("pinned certificate must be a base64 encoded SHA-256 hash")
+	ErrCertificateNotPinned     = errors.New("server certificate does not match any pinned certificate")
 )
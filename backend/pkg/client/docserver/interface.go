@@ -22,4 +22,5 @@ import "context"
 type Client interface {
 	GetServerVersion(ctx context.Context, base string, opts ...Option) (*ServerVersionResponse, error)
 	ConvertFile(ctx context.Context, base, token string, opts ...Option) (*FileConversionResponse, error)
+	DownloadFile(ctx context.Context, fileURL string, opts ...Option) (*FileDownloadResponse, error)
 }
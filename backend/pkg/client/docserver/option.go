@@ -18,14 +18,17 @@
 package docserver
 
 type ClientOptions struct {
-	Token  string
-	Header string
+	Token         string
+	Header        string
+	PublicAddress string
+	TLS           TLSConfig
 }
 
 func DefaultClientOptions() *ClientOptions {
 	return &ClientOptions{
-		Token:  "",
-		Header: "",
+		Token:         "",
+		Header:        "",
+		PublicAddress: "",
 	}
 }
 
@@ -47,6 +50,21 @@ func WithHeader(header string) Option {
 	}
 }
 
+// WithPublicAddress sets the browser-facing Document Server address. When it
+// differs from the address a request is sent to, result URLs are rewritten
+// to point to the public address.
+func WithPublicAddress(address string) Option {
+	return func(o *ClientOptions) {
+		o.PublicAddress = address
+	}
+}
+
+func WithTLSConfig(config TLSConfig) Option {
+	return func(o *ClientOptions) {
+		o.TLS = config
+	}
+}
+
 func ApplyOptions(o *ClientOptions, opts ...Option) {
 	for _, opt := range opts {
 		opt(o)
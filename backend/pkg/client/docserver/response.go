@@ -17,6 +17,8 @@
  */
 package docserver
 
+import "io"
+
 type ServerVersionResponse struct {
 	Error   int    `json:"error"`
 	Version string `json:"version"`
@@ -27,3 +29,9 @@ type FileConversionResponse struct {
 	FileURL string `json:"fileUrl"`
 	Percent int    `json:"percent"`
 }
+
+// FileDownloadResponse holds a file content stream. Callers must close Body.
+type FileDownloadResponse struct {
+	Body          io.ReadCloser
+	ContentLength int64
+}
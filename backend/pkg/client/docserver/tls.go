@@ -0,0 +1,138 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package docserver
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/core/component"
+)
+
+// TLSConfig describes how to trust a self-hosted Document Server.
+// CABundle, ClientCertificate and ClientKey are PEM encoded. PinnedCertificates
+// are base64 encoded SHA-256 hashes of a certificate's SubjectPublicKeyInfo,
+// optionally prefixed with "sha256/".
+type TLSConfig struct {
+	CABundle           string
+	ClientCertificate  string
+	ClientKey          string
+	PinnedCertificates []string
+	InsecureSkipVerify bool
+}
+
+// NewTLSConfig maps decrypted TLS settings to the client configuration.
+func NewTLSConfig(settings component.TLS) TLSConfig {
+	return TLSConfig{
+		CABundle:           settings.CABundle,
+		ClientCertificate:  settings.ClientCertificate,
+		ClientKey:          settings.ClientKey,
+		PinnedCertificates: settings.PinnedCertificates,
+		InsecureSkipVerify: settings.InsecureSkipVerify,
+	}
+}
+
+func (c TLSConfig) IsEmpty() bool {
+	return c.CABundle == "" &&
+		c.ClientCertificate == "" &&
+		c.ClientKey == "" &&
+		len(c.PinnedCertificates) == 0 &&
+		!c.InsecureSkipVerify
+}
+
+// Fingerprint returns a stable identifier of the configuration used to key
+// cached transports.
+func (c TLSConfig) Fingerprint() string {
+	hasher := sha256.New()
+	hasher.Write([]byte(c.CABundle))
+	hasher.Write([]byte{0})
+	hasher.Write([]byte(c.ClientCertificate))
+	hasher.Write([]byte{0})
+	hasher.Write([]byte(c.ClientKey))
+	hasher.Write([]byte{0})
+	hasher.Write([]byte(strings.Join(c.PinnedCertificates, ",")))
+	if c.InsecureSkipVerify {
+		hasher.Write([]byte{1})
+	}
+
+	return hex.EncodeToString(hasher.Sum(nil))
+}
+
+func (c TLSConfig) Validate() error {
+	_, err := c.Build()
+	return err
+}
+
+func (c TLSConfig) Build() (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: c.InsecureSkipVerify,
+	}
+
+	if c.CABundle != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+
+		if !pool.AppendCertsFromPEM([]byte(c.CABundle)) {
+			return nil, ErrInvalidCABundle
+		}
+
+		config.RootCAs = pool
+	}
+
+	if c.ClientCertificate != "" || c.ClientKey != "" {
+		certificate, err := tls.X509KeyPair([]byte(c.ClientCertificate), []byte(c.ClientKey))
+		if err != nil {
+			return nil, errors.Join(ErrInvalidClientCertificate, err)
+		}
+
+		config.Certificates = []tls.Certificate{certificate}
+	}
+
+	if len(c.PinnedCertificates) > 0 {
+		pins := make(map[string]struct{}, len(c.PinnedCertificates))
+		for _, pin := range c.PinnedCertificates {
+			decoded, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(strings.TrimSpace(pin), "sha256/"))
+			if err != nil || len(decoded) != sha256.Size {
+				return nil, ErrInvalidPinnedCertificate
+			}
+
+			pins[string(decoded)] = struct{}{}
+		}
+
+		config.VerifyConnection = func(state tls.ConnectionState) error {
+			for _, certificate := range state.PeerCertificates {
+				hash := sha256.Sum256(certificate.RawSubjectPublicKeyInfo)
+				if _, ok := pins[string(hash[:])]; ok {
+					return nil
+				}
+			}
+
+			return ErrCertificateNotPinned
+		}
+	}
+
+	return config, nil
+}
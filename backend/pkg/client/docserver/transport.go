@@ -0,0 +1,94 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package docserver
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/common"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/breaker"
+)
+
+const maxCachedTransports = 128
+
+func newTransport() *http.Transport {
+	return &http.Transport{
+		MaxIdleConnsPerHost:    100,
+		IdleConnTimeout:        90 * time.Second,
+		MaxResponseHeaderBytes: 1 << 20,
+		DisableCompression:     false,
+		ForceAttemptHTTP2:      true,
+	}
+}
+
+// transportCache keeps one HTTP client per distinct TLS configuration so
+// connections to self-hosted Document Servers are reused between requests.
+type transportCache struct {
+	mu      sync.Mutex
+	timeout time.Duration
+	guards  *breaker.Registry
+	clients map[string]*http.Client
+	order   []string
+}
+
+func newTransportCache(timeout time.Duration, guards *breaker.Registry) *transportCache {
+	return &transportCache{
+		timeout: timeout,
+		guards:  guards,
+		clients: make(map[string]*http.Client),
+	}
+}
+
+func (c *transportCache) Get(config TLSConfig) (*http.Client, error) {
+	key := config.Fingerprint()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if client, ok := c.clients[key]; ok {
+		return client, nil
+	}
+
+	tlsConfig, err := config.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	transport := newTransport()
+	transport.TLSClientConfig = tlsConfig
+
+	client := &http.Client{
+		Timeout:   c.timeout,
+		Transport: breaker.NewTransport(common.NewRetryableTransport(transport), c.guards),
+	}
+
+	if len(c.order) >= maxCachedTransports {
+		evicted := c.order[0]
+		c.order = c.order[1:]
+		if old, ok := c.clients[evicted]; ok {
+			old.CloseIdleConnections()
+			delete(c.clients, evicted)
+		}
+	}
+
+	c.clients[key] = client
+	c.order = append(c.order, key)
+	return client, nil
+}
@@ -22,12 +22,14 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"math/big"
 	"mime/multipart"
 	"net/http"
 	"net/textproto"
+	"net/url"
 	"path/filepath"
 	"strings"
 	"time"
@@ -36,31 +38,78 @@ import (
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/config"
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/service"
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/common"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/breaker"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/metrics"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/tracing"
+	"go.opentelemetry.io/otel"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/client/miro")
+
+// Operations label the calls made to the Miro API in metrics and traces.
+const (
+	operationGetBoard          = "get_board"
+	operationGetBoardMember    = "get_board_member"
+	operationGetFileInfo       = "get_file_info"
+	operationGetFilesInfo      = "get_files_info"
+	operationGetFilePublicURL  = "get_file_public_url"
+	operationDownloadFile      = "download_file"
+	operationGetUserInfo       = "get_user_info"
+	operationCreateFile        = "create_file"
+	operationUploadFile        = "upload_file"
+	operationUploadFileContent = "upload_file_content"
 )
 
 type client struct {
-	baseUrl    string
-	httpClient *http.Client
-	errors     *Errors
-	logger     service.Logger
+	baseUrl        string
+	httpClient     *http.Client
+	downloadClient *http.Client
+	uploadClient   *http.Client
+	budget         *creditBudget
+	errors         *Errors
+	metrics        *metrics.Metrics
+	logger         service.Logger
 }
 
-func NewMiroClient(config *config.MiroConfig, logger service.Logger) Client {
+// NewMiroClient creates a Miro REST client. Requests to each host are guarded
+// by the circuit breaker and bulkhead taken from guards, when provided.
+func NewMiroClient(
+	config *config.MiroConfig,
+	guards *breaker.Registry,
+	metrics *metrics.Metrics,
+	logger service.Logger,
+) Client {
+	baseTransport := &http.Transport{
+		MaxIdleConnsPerHost:    100,
+		IdleConnTimeout:        90 * time.Second,
+		MaxResponseHeaderBytes: 1 << 20,
+		DisableCompression:     false,
+		ForceAttemptHTTP2:      true,
+	}
+	transport := breaker.NewTransport(common.NewRetryableTransport(baseTransport), guards)
+	errors := NewErrors()
+
 	return &client{
 		baseUrl: config.BaseURL,
-		errors:  NewErrors(),
+		budget:  newCreditBudget(config.CreditReserve, config.MaxRateLimitWait, errors),
+		errors:  errors,
 		httpClient: &http.Client{
-			Timeout: config.Timeout,
-			Transport: common.NewRetryableTransport(&http.Transport{
-				MaxIdleConnsPerHost:    100,
-				IdleConnTimeout:        90 * time.Second,
-				MaxResponseHeaderBytes: 1 << 20,
-				DisableCompression:     false,
-				ForceAttemptHTTP2:      true,
-			},
-			),
+			Timeout:   config.Timeout,
+			Transport: transport,
 		},
-		logger: logger,
+		// Downloads are streamed, so they are bound by the request context
+		// rather than by a client timeout.
+		downloadClient: &http.Client{
+			Transport: transport,
+		},
+		// Streamed uploads cannot be replayed, so they skip the retrying transport.
+		uploadClient: &http.Client{
+			Transport: breaker.NewTransport(baseTransport, guards),
+		},
+		metrics: metrics,
+		logger:  logger,
 	}
 }
 
@@ -74,13 +123,60 @@ func (c *client) buildURL(paths ...string) string {
 
 func (c *client) sendRequest(
 	ctx context.Context,
-	method, url, token string,
+	operation, method, url, token string,
+	body io.Reader,
+	headers map[string]string,
+	result any,
+) error {
+	return c.doRequest(ctx, c.httpClient, operation, method, url, token, body, headers, result, false)
+}
+
+// sendBulkRequest sends a request that is part of a larger batch, such as a
+// page of a listing, which must not eat into the credits reserved for
+// interactive requests.
+func (c *client) sendBulkRequest(ctx context.Context, operation, method, url, token string, result any) error {
+	return c.doRequest(ctx, c.httpClient, operation, method, url, token, nil, nil, result, true)
+}
+
+func (c *client) doRequest(
+	ctx context.Context,
+	httpClient *http.Client,
+	operation, method, url, token string,
 	body io.Reader,
 	headers map[string]string,
 	result any,
+	bulk bool,
 ) error {
+	ctx, span := tracer.Start(ctx, "miro."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.HTTPRequestMethodKey.String(method)),
+	)
+
 	c.logger.Info(ctx, fmt.Sprintf("Sending %s request to %s", method, url))
 
+	if err := c.budget.acquire(ctx, token, requestCost(method), bulk); err != nil {
+		c.logger.Warn(ctx, fmt.Sprintf("Rate limit credits exhausted: %v", err))
+		tracing.End(span, err)
+		return err
+	}
+
+	start := time.Now()
+	err := c.exchange(ctx, httpClient, method, url, token, body, headers, result)
+	c.metrics.ObserveUpstream(metrics.ClientMiro, operation, time.Since(start), err)
+
+	tracing.End(span, err)
+	return err
+}
+
+// exchange sends a single request to the Miro API and decodes its response.
+func (c *client) exchange(
+	ctx context.Context,
+	httpClient *http.Client,
+	method, url, token string,
+	body io.Reader,
+	headers map[string]string,
+	result any,
+) error {
 	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
 		c.logger.Error(ctx, fmt.Sprintf("Failed to create request: %v", err))
@@ -97,16 +193,24 @@ func (c *client) sendRequest(
 		req.Header.Set(k, v)
 	}
 
-	res, err := c.httpClient.Do(req)
+	res, err := httpClient.Do(req)
 	if err != nil {
 		c.logger.Error(ctx, fmt.Sprintf("Failed to send request: %v", err))
 		return c.errors.FailedToSendRequest(err)
 	}
 
 	defer res.Body.Close()
+	c.budget.update(token, res)
+	trace.SpanFromContext(ctx).SetAttributes(semconv.HTTPResponseStatusCode(res.StatusCode))
+
+	if res.StatusCode == http.StatusTooManyRequests {
+		c.logger.Warn(ctx, "Request rejected by Miro rate limit")
+		return c.errors.RateLimited(retryAfter(res))
+	}
+
 	if res.StatusCode >= 300 || res.StatusCode < 200 {
 		c.logger.Error(ctx, fmt.Sprintf("Request failed with status code: %d", res.StatusCode))
-		return c.errors.RequestFailed(res.StatusCode)
+		return c.errors.RequestFailed(res)
 	}
 
 	c.logger.Debug(ctx, fmt.Sprintf("Request successful with status code: %d", res.StatusCode))
@@ -133,7 +237,7 @@ func (c *client) GetBoard(ctx context.Context, req GetBoardRequest) (*BoardRespo
 
 	var response BoardResponse
 	url := c.buildURL("boards", req.BoardID)
-	if err := c.sendRequest(ctx, http.MethodGet, url, req.Token, nil, nil, &response); err != nil {
+	if err := c.sendRequest(ctx, operationGetBoard, http.MethodGet, url, req.Token, nil, nil, &response); err != nil {
 		return nil, c.errors.FailedToGetBoard(err)
 	}
 
@@ -156,7 +260,7 @@ func (c *client) GetBoardMember(ctx context.Context, req GetBoardMemberRequest)
 
 	var response BoardMemberResponse
 	url := c.buildURL("boards", req.BoardID, "members", req.MemberID)
-	if err := c.sendRequest(ctx, http.MethodGet, url, req.Token, nil, nil, &response); err != nil {
+	if err := c.sendRequest(ctx, operationGetBoardMember, http.MethodGet, url, req.Token, nil, nil, &response); err != nil {
 		return nil, c.errors.FailedToGetBoardMember(err)
 	}
 
@@ -180,7 +284,7 @@ func (c *client) GetFileInfo(ctx context.Context, req GetFileInfoRequest) (*File
 
 	var response FileInfoResponse
 	url := c.buildURL("boards", req.BoardID, "items", req.ItemID)
-	if err := c.sendRequest(ctx, http.MethodGet, url, req.Token, nil, nil, &response); err != nil {
+	if err := c.sendRequest(ctx, operationGetFileInfo, http.MethodGet, url, req.Token, nil, nil, &response); err != nil {
 		return nil, c.errors.FailedToGetFileInfo(err)
 	}
 
@@ -214,7 +318,7 @@ func (c *client) GetFilesInfo(ctx context.Context, req GetFilesInfoRequest) (*Fi
 		})
 	}
 
-	if err := c.sendRequest(ctx, http.MethodGet, url, req.Token, nil, nil, &response); err != nil {
+	if err := c.sendBulkRequest(ctx, operationGetFilesInfo, http.MethodGet, url, req.Token, &response); err != nil {
 		return nil, c.errors.FailedToGetFileInfo(err)
 	}
 
@@ -236,7 +340,7 @@ func (c *client) GetFilePublicURL(ctx context.Context, req GetFilePublicURLReque
 	}
 
 	var response FileLocationResponse
-	if err := c.sendRequest(ctx, http.MethodGet, req.URL, req.Token, nil, nil, &response); err != nil {
+	if err := c.sendRequest(ctx, operationGetFilePublicURL, http.MethodGet, req.URL, req.Token, nil, nil, &response); err != nil {
 		return nil, c.errors.FailedToGetFileURL(err)
 	}
 
@@ -244,6 +348,67 @@ func (c *client) GetFilePublicURL(ctx context.Context, req GetFilePublicURLReque
 	return &response, nil
 }
 
+func (c *client) DownloadFile(ctx context.Context, req DownloadFileRequest) (*FileDownloadResponse, error) {
+	if err := req.Validate(); err != nil {
+		c.logger.Error(ctx, "Invalid download file request", service.Fields{"error": err.Error()})
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, req.URL, nil)
+	if err != nil {
+		c.logger.Error(ctx, "Failed to create download request")
+		return nil, c.errors.FailedToDownloadFile(c.errors.FailedToCreateRequest(err))
+	}
+
+	// The URL is pre-signed, so only its host is safe to log.
+	c.logger.Debug(ctx, "Downloading file", service.Fields{
+		"host": httpReq.URL.Host,
+	})
+
+	_, span := tracer.Start(ctx, "miro."+operationDownloadFile,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.HTTPRequestMethodKey.String(http.MethodGet)),
+	)
+
+	start := time.Now()
+	observe := func(err error) {
+		c.metrics.ObserveUpstream(metrics.ClientMiro, operationDownloadFile, time.Since(start), err)
+		tracing.End(span, err)
+	}
+
+	res, err := c.downloadClient.Do(httpReq)
+	if err != nil {
+		// Drop the *url.Error wrapper so the pre-signed URL is not logged.
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+
+		observe(err)
+		c.logger.Error(ctx, fmt.Sprintf("Failed to send request: %v", err))
+		return nil, c.errors.FailedToDownloadFile(c.errors.FailedToSendRequest(err))
+	}
+
+	span.SetAttributes(semconv.HTTPResponseStatusCode(res.StatusCode))
+	if res.StatusCode >= 300 || res.StatusCode < 200 {
+		defer res.Body.Close()
+		err := c.errors.RequestFailed(res)
+		observe(err)
+		c.logger.Error(ctx, fmt.Sprintf("Request failed with status code: %d", res.StatusCode))
+		return nil, c.errors.FailedToDownloadFile(err)
+	}
+
+	// Only the time to the first byte is observed, the body is streamed by the caller.
+	observe(nil)
+
+	c.logger.Debug(ctx, "Successfully started file download")
+	return &FileDownloadResponse{
+		Body:          res.Body,
+		ContentType:   res.Header.Get("Content-Type"),
+		ContentLength: res.ContentLength,
+	}, nil
+}
+
 func (c *client) GetUserInfo(ctx context.Context, req GetUserInfoRequest) (*UserInfoResponse, error) {
 	c.logger.Info(ctx, "Getting user info", service.Fields{
 		"token": req.Token,
@@ -256,7 +421,7 @@ func (c *client) GetUserInfo(ctx context.Context, req GetUserInfoRequest) (*User
 
 	var response UserInfoResponse
 	url := "https://api.miro.com/v1/oauth-token"
-	if err := c.sendRequest(ctx, http.MethodGet, url, req.Token, nil, nil, &response); err != nil {
+	if err := c.sendRequest(ctx, operationGetUserInfo, http.MethodGet, url, req.Token, nil, nil, &response); err != nil {
 		return nil, err
 	}
 
@@ -324,6 +489,62 @@ func (c *client) createMultipartForm(data map[string]any, filePath string, posit
 	return body, writer.FormDataContentType(), nil
 }
 
+// streamMultipartForm writes the form through a pipe so that content is sent
+// to Miro while it is still being read, without buffering the whole file.
+func (c *client) streamMultipartForm(data map[string]any, filename string, content io.Reader) (io.ReadCloser, string) {
+	reader, pipe := io.Pipe()
+	writer := multipart.NewWriter(pipe)
+
+	go func() {
+		ctx := context.Background()
+		payload, err := json.Marshal(data)
+		if err != nil {
+			c.logger.Error(ctx, fmt.Sprintf("Failed to marshal form data: %v", err))
+			pipe.CloseWithError(c.errors.FailedToMarshalRequest(err))
+			return
+		}
+
+		h := make(textproto.MIMEHeader)
+		h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"`, "data"))
+		h.Set("Content-Type", "application/json")
+		part, err := writer.CreatePart(h)
+		if err != nil {
+			c.logger.Error(ctx, fmt.Sprintf("Failed to create form part: %v", err))
+			pipe.CloseWithError(c.errors.FailedToCreateFormFile(err))
+			return
+		}
+
+		if _, err := part.Write(payload); err != nil {
+			c.logger.Error(ctx, fmt.Sprintf("Failed to write form data: %v", err))
+			pipe.CloseWithError(c.errors.FailedToWriteFileData(err))
+			return
+		}
+
+		fileWriter, err := writer.CreateFormFile("resource", filename)
+		if err != nil {
+			c.logger.Error(ctx, fmt.Sprintf("Failed to create form file: %v", err))
+			pipe.CloseWithError(c.errors.FailedToCreateFormFile(err))
+			return
+		}
+
+		if _, err := io.Copy(fileWriter, content); err != nil {
+			c.logger.Error(ctx, fmt.Sprintf("Failed to write file data: %v", err))
+			pipe.CloseWithError(c.errors.FailedToWriteFileData(err))
+			return
+		}
+
+		if err := writer.Close(); err != nil {
+			c.logger.Error(ctx, fmt.Sprintf("Failed to close form writer: %v", err))
+			pipe.CloseWithError(c.errors.FailedToCloseWriter(err))
+			return
+		}
+
+		pipe.Close()
+	}()
+
+	return reader, writer.FormDataContentType()
+}
+
 func (c *client) CreateFile(ctx context.Context, req CreateFileRequest) (*FileCreatedResponse, error) {
 	c.logger.Info(ctx, "Creating file", service.Fields{
 		"boardId":  req.BoardID,
@@ -380,7 +601,7 @@ func (c *client) CreateFile(ctx context.Context, req CreateFileRequest) (*FileCr
 	}
 
 	var response FileCreatedResponse
-	if err := c.sendRequest(ctx, http.MethodPost, url, req.Token, body, headers, &response); err != nil {
+	if err := c.sendRequest(ctx, operationCreateFile, http.MethodPost, url, req.Token, body, headers, &response); err != nil {
 		return nil, err
 	}
 
@@ -423,7 +644,7 @@ func (c *client) UploadFile(ctx context.Context, req UploadFileRequest) (*FileLo
 	}
 
 	var response FileLocationResponse
-	if err := c.sendRequest(ctx, http.MethodPatch, url, req.Token, bytes.NewBuffer(payload), headers, &response); err != nil {
+	if err := c.sendRequest(ctx, operationUploadFile, http.MethodPatch, url, req.Token, bytes.NewBuffer(payload), headers, &response); err != nil {
 		return nil, c.errors.FailedToUploadFile(err)
 	}
 
@@ -433,3 +654,36 @@ func (c *client) UploadFile(ctx context.Context, req UploadFileRequest) (*FileLo
 	})
 	return &response, nil
 }
+
+func (c *client) UploadFileContent(ctx context.Context, req UploadFileContentRequest) (*FileLocationResponse, error) {
+	c.logger.Info(ctx, "Uploading file content", service.Fields{
+		"boardId": req.BoardID,
+		"itemId":  req.ItemID,
+	})
+
+	if err := req.Validate(); err != nil {
+		c.logger.Error(ctx, fmt.Sprintf("Invalid upload file content request: %v", err))
+		return nil, err
+	}
+
+	body, contentType := c.streamMultipartForm(map[string]any{
+		"title": req.Filename,
+	}, filepath.Base(req.Filename), req.Content)
+	defer body.Close()
+
+	url := c.buildURL("boards", req.BoardID, "documents", req.ItemID)
+	headers := map[string]string{
+		"Content-Type": contentType,
+	}
+
+	var response FileLocationResponse
+	if err := c.doRequest(ctx, c.uploadClient, operationUploadFileContent, http.MethodPatch, url, req.Token, body, headers, &response, false); err != nil {
+		return nil, c.errors.FailedToUploadFile(err)
+	}
+
+	c.logger.Debug(ctx, "Successfully uploaded file content", service.Fields{
+		"boardId": req.BoardID,
+		"itemId":  req.ItemID,
+	})
+	return &response, nil
+}
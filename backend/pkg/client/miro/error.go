@@ -18,8 +18,13 @@
 package miro
 
 import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/common"
 )
@@ -49,11 +54,60 @@ func (e *BaseError) Unwrap() error {
 type CreateRequestError struct{ BaseError }
 type SendRequestError struct{ BaseError }
 type DecodeResponseError struct{ BaseError }
-type RequestFailedError struct{ BaseError }
+
+// RequestFailedError describes a response from the Miro API with an
+// unsuccessful status, along with the error details Miro sent in its body.
+type RequestFailedError struct {
+	BaseError
+	statusCode int
+	code       string
+	reason     string
+	requestID  string
+}
+
+// StatusCode returns the HTTP status code sent by Miro.
+func (e *RequestFailedError) StatusCode() int {
+	return e.statusCode
+}
+
+// Code returns the Miro error code, e.g. "3.0604", if one was sent.
+func (e *RequestFailedError) Code() string {
+	return e.code
+}
+
+// Reason returns the error message sent by Miro.
+func (e *RequestFailedError) Reason() string {
+	return e.reason
+}
+
+// RequestID returns the identifier Miro assigned to the request.
+func (e *RequestFailedError) RequestID() string {
+	return e.requestID
+}
+
+// errorResponse is the error body returned by the Miro REST API.
+type errorResponse struct {
+	Status  int    `json:"status"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+const (
+	headerRequestID  = "X-Request-Id"
+	maxErrorBodySize = 64 << 10
+)
+
+// RateLimitedError is returned when Miro keeps rejecting a request with 429 or
+// the token's credits do not recover within the configured wait.
+type RateLimitedError struct {
+	BaseError
+	RetryAfter time.Duration
+}
 
 type GetFileInfoError struct{ BaseError }
 type GetFilePublicURLError struct{ BaseError }
 type UploadFileError struct{ BaseError }
+type DownloadFileError struct{ BaseError }
 type MarshalRequestError struct{ BaseError }
 type ReadResponseError struct{ BaseError }
 
@@ -69,10 +123,12 @@ type Errors struct {
 	FailedToSendRequest    func(err error) error
 	FailedToDecodeResponse func(err error) error
 	FailedToReadResponse   func(err error) error
-	RequestFailed          func(statusCode int) error
+	RequestFailed          func(res *http.Response) error
+	RateLimited            func(retryAfter time.Duration) error
 	FailedToGetFileInfo    func(err error) error
 	FailedToGetFileURL     func(err error) error
 	FailedToUploadFile     func(err error) error
+	FailedToDownloadFile   func(err error) error
 	FailedToMarshalRequest func(err error) error
 	FailedToGetBoard       func(err error) error
 	FailedToGetBoardMember func(err error) error
@@ -108,10 +164,46 @@ func NewErrors() *Errors {
 				err:     err,
 			}}
 		},
-		RequestFailed: func(statusCode int) error {
-			return &RequestFailedError{BaseError{
-				message: common.Concat("Request failed with status ", strconv.Itoa(statusCode)),
-			}}
+		RequestFailed: func(res *http.Response) error {
+			failed := &RequestFailedError{
+				statusCode: res.StatusCode,
+				requestID:  res.Header.Get(headerRequestID),
+			}
+
+			var body errorResponse
+			if err := json.NewDecoder(io.LimitReader(res.Body, maxErrorBodySize)).Decode(&body); err == nil {
+				failed.code = body.Code
+				failed.reason = body.Message
+			}
+
+			var b strings.Builder
+			b.WriteString("Request failed with status ")
+			b.WriteString(strconv.Itoa(res.StatusCode))
+			if failed.reason != "" {
+				b.WriteString(": ")
+				b.WriteString(failed.reason)
+			}
+			if failed.code != "" {
+				b.WriteString(" (code ")
+				b.WriteString(failed.code)
+				b.WriteString(")")
+			}
+			if failed.requestID != "" {
+				b.WriteString(" [request ")
+				b.WriteString(failed.requestID)
+				b.WriteString("]")
+			}
+
+			failed.message = b.String()
+			return failed
+		},
+		RateLimited: func(retryAfter time.Duration) error {
+			return &RateLimitedError{
+				BaseError: BaseError{
+					message: common.Concat("Rate limited, retry after ", retryAfter.String()),
+				},
+				RetryAfter: retryAfter,
+			}
 		},
 		FailedToGetFileInfo: func(err error) error {
 			return &GetFileInfoError{BaseError{
@@ -131,6 +223,12 @@ func NewErrors() *Errors {
 				err:     err,
 			}}
 		},
+		FailedToDownloadFile: func(err error) error {
+			return &DownloadFileError{BaseError{
+				message: common.Concat("Failed to download file"),
+				err:     err,
+			}}
+		},
 		FailedToMarshalRequest: func(err error) error {
 			return &MarshalRequestError{BaseError{
 				message: common.Concat("Failed to marshal request"),
@@ -175,3 +273,44 @@ func NewErrors() *Errors {
 		},
 	}
 }
+
+// StatusCode returns the HTTP status of the Miro response err was built from.
+// Rate limited requests report 429 even when credits ran out locally.
+func StatusCode(err error) (int, bool) {
+	var limited *RateLimitedError
+	if errors.As(err, &limited) {
+		return http.StatusTooManyRequests, true
+	}
+
+	var failed *RequestFailedError
+	if errors.As(err, &failed) {
+		return failed.StatusCode(), true
+	}
+
+	return 0, false
+}
+
+func hasStatus(err error, status int) bool {
+	code, ok := StatusCode(err)
+	return ok && code == status
+}
+
+// IsUnauthorized reports whether Miro rejected the access token.
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized)
+}
+
+// IsForbidden reports whether the token lacks access to the resource.
+func IsForbidden(err error) bool {
+	return hasStatus(err, http.StatusForbidden)
+}
+
+// IsNotFound reports whether the board or item does not exist.
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsRateLimited reports whether the request was rejected by a rate limit.
+func IsRateLimited(err error) bool {
+	return hasStatus(err, http.StatusTooManyRequests)
+}
@@ -25,8 +25,10 @@ type Client interface {
 	GetFileInfo(ctx context.Context, req GetFileInfoRequest) (*FileInfoResponse, error)
 	GetFilesInfo(ctx context.Context, req GetFilesInfoRequest) (*FilesInfoResponse, error)
 	GetFilePublicURL(ctx context.Context, req GetFilePublicURLRequest) (*FileLocationResponse, error)
+	DownloadFile(ctx context.Context, req DownloadFileRequest) (*FileDownloadResponse, error)
 	GetUserInfo(ctx context.Context, req GetUserInfoRequest) (*UserInfoResponse, error)
 
 	CreateFile(ctx context.Context, req CreateFileRequest) (*FileCreatedResponse, error)
 	UploadFile(ctx context.Context, req UploadFileRequest) (*FileLocationResponse, error)
+	UploadFileContent(ctx context.Context, req UploadFileContentRequest) (*FileLocationResponse, error)
 }
@@ -0,0 +1,185 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package miro
+
+import (
+	"context"
+	"crypto/sha256"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/common"
+)
+
+const (
+	headerRateLimitRemaining = "X-RateLimit-Remaining"
+	headerRateLimitReset     = "X-RateLimit-Reset"
+)
+
+// Request costs in credits, following the rate limit levels Miro assigns to
+// reading and writing endpoints.
+const (
+	readCost  = 50
+	writeCost = 100
+)
+
+// resetEpochThreshold tells epoch timestamps apart from relative seconds in
+// X-RateLimit-Reset.
+const resetEpochThreshold = 1_000_000_000
+
+func requestCost(method string) int {
+	if method == http.MethodGet {
+		return readCost
+	}
+
+	return writeCost
+}
+
+type tokenCredits struct {
+	remaining int
+	reset     time.Time
+}
+
+// creditBudget mirrors the rate limit credits Miro reports for each token.
+// Credits are spent locally between responses so that concurrent requests do
+// not all race for the last ones, and bulk requests stop at the reserve to
+// leave room for interactive ones.
+type creditBudget struct {
+	reserve int
+	maxWait time.Duration
+	errors  *Errors
+
+	mu        sync.Mutex
+	tokens    map[[sha256.Size]byte]*tokenCredits
+	lastSweep time.Time
+}
+
+func newCreditBudget(reserve int, maxWait time.Duration, errors *Errors) *creditBudget {
+	return &creditBudget{
+		reserve:   reserve,
+		maxWait:   maxWait,
+		errors:    errors,
+		tokens:    make(map[[sha256.Size]byte]*tokenCredits),
+		lastSweep: time.Now(),
+	}
+}
+
+// sweep drops tokens whose window has reset. The caller must hold the lock.
+func (b *creditBudget) sweep(now time.Time) {
+	if now.Sub(b.lastSweep) < time.Minute {
+		return
+	}
+
+	for key, credits := range b.tokens {
+		if now.After(credits.reset) {
+			delete(b.tokens, key)
+		}
+	}
+
+	b.lastSweep = now
+}
+
+// acquire spends cost credits of token, waiting for the window to reset when
+// they are exhausted. It fails with a rate limit error when the reset is
+// further away than the maximum wait.
+func (b *creditBudget) acquire(ctx context.Context, token string, cost int, bulk bool) error {
+	key := sha256.Sum256([]byte(token))
+	floor := 0
+	if bulk {
+		floor = b.reserve
+	}
+
+	for {
+		now := time.Now()
+
+		b.mu.Lock()
+		b.sweep(now)
+		credits, ok := b.tokens[key]
+		if !ok || now.After(credits.reset) || credits.remaining-cost >= floor {
+			if ok {
+				credits.remaining -= cost
+			}
+			b.mu.Unlock()
+			return nil
+		}
+		wait := credits.reset.Sub(now)
+		b.mu.Unlock()
+
+		if wait > b.maxWait {
+			return b.errors.RateLimited(wait)
+		}
+
+		if err := common.Sleep(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// update records the credits reported by a response. Rejected requests
+// without rate limit headers fall back to Retry-After.
+func (b *creditBudget) update(token string, res *http.Response) {
+	now := time.Now()
+	credits := tokenCredits{remaining: -1}
+
+	if remaining, err := strconv.Atoi(res.Header.Get(headerRateLimitRemaining)); err == nil {
+		credits.remaining = remaining
+	}
+
+	if reset, err := strconv.ParseInt(res.Header.Get(headerRateLimitReset), 10, 64); err == nil {
+		if reset > resetEpochThreshold {
+			credits.reset = time.Unix(reset, 0)
+		} else {
+			credits.reset = now.Add(time.Duration(reset) * time.Second)
+		}
+	}
+
+	if res.StatusCode == http.StatusTooManyRequests {
+		credits.remaining = 0
+		if retryAfter, ok := common.ParseRetryAfter(res.Header); ok {
+			credits.reset = now.Add(retryAfter)
+		}
+	}
+
+	if credits.remaining < 0 || !credits.reset.After(now) {
+		return
+	}
+
+	key := sha256.Sum256([]byte(token))
+
+	b.mu.Lock()
+	b.tokens[key] = &credits
+	b.mu.Unlock()
+}
+
+// retryAfter returns how long to wait before retrying a rejected request.
+func retryAfter(res *http.Response) time.Duration {
+	if retryAfter, ok := common.ParseRetryAfter(res.Header); ok {
+		return retryAfter
+	}
+
+	if reset, err := strconv.ParseInt(res.Header.Get(headerRateLimitReset), 10, 64); err == nil {
+		if reset > resetEpochThreshold {
+			return max(0, time.Until(time.Unix(reset, 0)))
+		}
+		return time.Duration(reset) * time.Second
+	}
+
+	return 0
+}
@@ -19,6 +19,7 @@ package miro
 
 import (
 	"fmt"
+	"io"
 	"strings"
 
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/common"
@@ -143,6 +144,50 @@ func (r *GetFilePublicURLRequest) Validate() error {
 	return nil
 }
 
+type UploadFileContentRequest struct {
+	BoardID  string
+	ItemID   string
+	Filename string
+	Content  io.Reader
+	Token    string
+}
+
+func (r *UploadFileContentRequest) Validate() error {
+	if strings.TrimSpace(r.BoardID) == "" {
+		return fmt.Errorf("boardID is required")
+	}
+
+	if strings.TrimSpace(r.ItemID) == "" {
+		return fmt.Errorf("itemID is required")
+	}
+
+	if strings.TrimSpace(r.Filename) == "" {
+		return fmt.Errorf("filename is required")
+	}
+
+	if r.Content == nil {
+		return fmt.Errorf("content is required")
+	}
+
+	if strings.TrimSpace(r.Token) == "" {
+		return fmt.Errorf("token is required")
+	}
+
+	return nil
+}
+
+type DownloadFileRequest struct {
+	URL string
+}
+
+func (r *DownloadFileRequest) Validate() error {
+	if strings.TrimSpace(r.URL) == "" {
+		return fmt.Errorf("url is required")
+	}
+
+	return nil
+}
+
 type GetFilesInfoRequest struct {
 	Cursor  string `json:"cursor"`
 	BoardID string `json:"board_id"`
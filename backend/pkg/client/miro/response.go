@@ -17,6 +17,8 @@
  */
 package miro
 
+import "io"
+
 type AuthenticationResponse struct {
 	UserID       string `json:"user_id"`
 	TeamID       string `json:"team_id"`
@@ -79,6 +81,13 @@ type FileLocationResponse struct {
 	URL string `json:"url"`
 }
 
+// FileDownloadResponse holds a file content stream. Callers must close Body.
+type FileDownloadResponse struct {
+	Body          io.ReadCloser
+	ContentType   string
+	ContentLength int64
+}
+
 type FilesInfoResponse struct {
 	Size   int                `json:"size"`
 	Limit  int                `json:"limit"`
@@ -18,8 +18,10 @@
 package oauth
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/url"
 	"strings"
@@ -27,8 +29,13 @@ import (
 
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/config"
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/service"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/breaker"
 )
 
+// maxErrorBodySize bounds how much of a failed response is read for its
+// error code.
+const maxErrorBodySize = 4 << 10
+
 type client[T any] struct {
 	config     *config.OAuthConfig
 	httpClient *http.Client
@@ -36,7 +43,7 @@ type client[T any] struct {
 	logger     service.Logger
 }
 
-func NewOAuthClient[T any](config *config.OAuthConfig, logger service.Logger) (OAuthClient[T], error) {
+func NewOAuthClient[T any](config *config.OAuthConfig, guards *breaker.Registry, logger service.Logger) (OAuthClient[T], error) {
 	if err := config.Validate(); err != nil {
 		return nil, err
 	}
@@ -46,19 +53,43 @@ func NewOAuthClient[T any](config *config.OAuthConfig, logger service.Logger) (O
 		errors: NewErrors(),
 		httpClient: &http.Client{
 			Timeout: config.Timeout,
-			Transport: &http.Transport{
+			Transport: breaker.NewTransport(&http.Transport{
 				MaxIdleConnsPerHost:    50,
 				IdleConnTimeout:        90 * time.Second,
 				ResponseHeaderTimeout:  1500 * time.Millisecond,
 				MaxResponseHeaderBytes: 1 << 20,
 				DisableCompression:     false,
 				ForceAttemptHTTP2:      true,
-			},
+			}, guards),
 		},
 		logger: logger,
 	}, nil
 }
 
+// errorResponse is the RFC 6749 section 5.2 error body.
+type errorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// revokeRequest is the JSON body of Miro's v2 token revocation endpoint.
+type revokeRequest struct {
+	AccessToken  string `json:"accessToken"`
+	ClientID     string `json:"clientId"`
+	ClientSecret string `json:"clientSecret"`
+}
+
+// readErrorCode extracts the RFC 6749 error code from a failed response.
+// Bodies that are not JSON yield an empty code.
+func readErrorCode(resp *http.Response) string {
+	var body errorResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxErrorBodySize)).Decode(&body); err != nil {
+		return ""
+	}
+
+	return body.Error
+}
+
 func (c *client[T]) buildFormData(params map[string]string) url.Values {
 	data := url.Values{}
 	for key, value := range params {
@@ -94,10 +125,12 @@ func (c *client[T]) doRequest(ctx context.Context, url string, data url.Values)
 
 	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
+		code := readErrorCode(resp)
 		c.logger.Error(ctx, "Request failed", service.Fields{
 			"status_code": resp.StatusCode,
+			"error_code":  code,
 		})
-		return response, c.errors.RequestFailed(resp.StatusCode)
+		return response, c.errors.RequestFailed(resp.StatusCode, code)
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
@@ -112,6 +145,12 @@ func (c *client[T]) doRequest(ctx context.Context, url string, data url.Values)
 }
 
 func (c *client[T]) Exchange(ctx context.Context, code string) (T, error) {
+	return c.ExchangeWithVerifier(ctx, code, "")
+}
+
+// ExchangeWithVerifier exchanges an authorization code, sending the PKCE
+// code verifier when one was used to start the authorization.
+func (c *client[T]) ExchangeWithVerifier(ctx context.Context, code, verifier string) (T, error) {
 	var zero T
 	c.logger.Debug(ctx, "Exchanging authorization code for token", service.Fields{
 		"code":         code,
@@ -128,6 +167,10 @@ func (c *client[T]) Exchange(ctx context.Context, code string) (T, error) {
 		"redirect_uri":  c.config.RedirectURI,
 	})
 
+	if verifier != "" {
+		data.Set("code_verifier", verifier)
+	}
+
 	address, err := url.Parse(c.config.TokenURI)
 	if err != nil {
 		c.logger.Error(ctx, "Failed to parse token URI", service.Fields{
@@ -189,3 +232,52 @@ func (c *client[T]) Refresh(ctx context.Context, refreshToken string) (T, error)
 	c.logger.Debug(ctx, "Token refresh successful", nil)
 	return response, nil
 }
+
+// Revoke invalidates an access token. Unlike the token endpoint, Miro's v2
+// revocation endpoint takes a JSON body rather than an RFC 7009 form.
+func (c *client[T]) Revoke(ctx context.Context, token string) error {
+	c.logger.Info(ctx, "Revoking token", nil)
+
+	body, err := json.Marshal(revokeRequest{
+		AccessToken:  token,
+		ClientID:     c.config.ClientID,
+		ClientSecret: c.config.ClientSecret,
+	})
+	if err != nil {
+		c.logger.Error(ctx, "Failed to encode revocation request", service.Fields{
+			"error": err.Error(),
+		})
+		return c.errors.FailedToRevokeToken(c.errors.FailedToCreateRequest(err))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.config.RevokeURI, bytes.NewReader(body))
+	if err != nil {
+		c.logger.Error(ctx, "Failed to create request", service.Fields{
+			"error": err.Error(),
+		})
+		return c.errors.FailedToRevokeToken(c.errors.FailedToCreateRequest(err))
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		c.logger.Error(ctx, "Failed to send request", service.Fields{
+			"error": err.Error(),
+		})
+		return c.errors.FailedToRevokeToken(c.errors.FailedToSendRequest(err))
+	}
+
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		code := readErrorCode(resp)
+		c.logger.Error(ctx, "Token revocation failed", service.Fields{
+			"status_code": resp.StatusCode,
+			"error_code":  code,
+		})
+		return c.errors.FailedToRevokeToken(c.errors.RequestFailed(resp.StatusCode, code))
+	}
+
+	c.logger.Debug(ctx, "Token revocation successful", nil)
+	return nil
+}
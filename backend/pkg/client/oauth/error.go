@@ -49,19 +49,36 @@ func (e *BaseError) Unwrap() error {
 type CreateRequestError struct{ BaseError }
 type SendRequestError struct{ BaseError }
 type DecodeResponseError struct{ BaseError }
-type RequestFailedError struct{ BaseError }
+type RequestFailedError struct {
+	BaseError
+	statusCode int
+	code       string
+}
+
+// StatusCode returns the HTTP status code sent by the authorization server.
+func (e *RequestFailedError) StatusCode() int {
+	return e.statusCode
+}
+
+// Code returns the RFC 6749 error code from the response body, such as
+// invalid_grant, or an empty string when the body carried none.
+func (e *RequestFailedError) Code() string {
+	return e.code
+}
 
 type ExchangeTokenError struct{ BaseError }
 type RefreshTokenError struct{ BaseError }
+type RevokeTokenError struct{ BaseError }
 
 type Errors struct {
 	FailedToCreateRequest  func(err error) error
 	FailedToSendRequest    func(err error) error
 	FailedToDecodeResponse func(err error) error
-	RequestFailed          func(statusCode int) error
+	RequestFailed          func(statusCode int, code string) error
 
 	FailedToExchangeToken func(err error) error
 	FailedToRefreshToken  func(err error) error
+	FailedToRevokeToken   func(err error) error
 }
 
 func NewErrors() *Errors {
@@ -84,10 +101,19 @@ func NewErrors() *Errors {
 				err:     err,
 			}}
 		},
-		RequestFailed: func(statusCode int) error {
-			return &RequestFailedError{BaseError{
-				message: common.Concat("Unexpected HTTP status ", strconv.Itoa(statusCode)),
-			}}
+		RequestFailed: func(statusCode int, code string) error {
+			message := common.Concat("Unexpected HTTP status ", strconv.Itoa(statusCode))
+			if code != "" {
+				message = common.Concat(message, " (", code, ")")
+			}
+
+			return &RequestFailedError{
+				BaseError: BaseError{
+					message: message,
+				},
+				statusCode: statusCode,
+				code:       code,
+			}
 		},
 		FailedToExchangeToken: func(err error) error {
 			return &ExchangeTokenError{BaseError{
@@ -101,5 +127,11 @@ func NewErrors() *Errors {
 				err:     err,
 			}}
 		},
+		FailedToRevokeToken: func(err error) error {
+			return &RevokeTokenError{BaseError{
+				message: common.Concat("Failed to revoke token"),
+				err:     err,
+			}}
+		},
 	}
 }
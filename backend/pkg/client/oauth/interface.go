@@ -21,5 +21,7 @@ import "context"
 
 type OAuthClient[T any] interface {
 	Exchange(ctx context.Context, code string) (T, error)
+	ExchangeWithVerifier(ctx context.Context, code, verifier string) (T, error)
 	Refresh(ctx context.Context, refreshToken string) (T, error)
+	Revoke(ctx context.Context, token string) error
 }
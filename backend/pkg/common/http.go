@@ -19,10 +19,13 @@ package common
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"math"
+	"math/rand/v2"
 	"net/http"
+	"strconv"
 	"time"
 )
 
@@ -38,10 +41,48 @@ const (
 const (
 	RetryCount  = 3
 	maxBodySize = 1 << 20
+	// MaxRetryAfter bounds how long a Retry-After header is honoured before
+	// the response is handed back to the caller instead.
+	MaxRetryAfter = 30 * time.Second
 )
 
+// backoff returns an exponentially growing delay with equal jitter, so that
+// clients throttled at the same moment do not retry in lockstep.
 func backoff(retries int) time.Duration {
-	return time.Duration(math.Pow(2, float64(retries))) * time.Second
+	delay := time.Duration(math.Pow(2, float64(retries))) * time.Second
+	return delay/2 + rand.N(delay/2)
+}
+
+// ParseRetryAfter reads a Retry-After header given either in seconds or as an
+// HTTP date.
+func ParseRetryAfter(header http.Header) (time.Duration, bool) {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(0, time.Until(date)), true
+	}
+
+	return 0, false
+}
+
+// Sleep waits for d or until ctx is done, whichever comes first.
+func Sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
 }
 
 func shouldRetry(err error, resp *http.Response) bool {
@@ -49,7 +90,8 @@ func shouldRetry(err error, resp *http.Response) bool {
 		return true
 	}
 
-	if resp.StatusCode == http.StatusBadGateway ||
+	if resp.StatusCode == http.StatusTooManyRequests ||
+		resp.StatusCode == http.StatusBadGateway ||
 		resp.StatusCode == http.StatusServiceUnavailable ||
 		resp.StatusCode == http.StatusGatewayTimeout {
 		return true
@@ -92,7 +134,26 @@ func (t *retryableTransport) RoundTrip(req *http.Request) (*http.Response, error
 	resp, err := t.transport.RoundTrip(req)
 	retries := 0
 	for shouldRetry(err, resp) && retries < RetryCount {
-		time.Sleep(backoff(retries))
+		if req.Context().Err() != nil {
+			break
+		}
+
+		delay := backoff(retries)
+		if resp != nil {
+			if retryAfter, ok := ParseRetryAfter(resp.Header); ok {
+				if retryAfter > MaxRetryAfter {
+					return resp, err
+				}
+				delay = max(delay, retryAfter)
+			}
+		}
+
+		if serr := Sleep(req.Context(), delay); serr != nil {
+			if resp != nil {
+				return resp, err
+			}
+			return nil, serr
+		}
 
 		drainBody(resp)
 
@@ -0,0 +1,59 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package admin
+
+import (
+	"net/http"
+
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/service"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/common"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/breaker"
+	echo "github.com/labstack/echo/v4"
+)
+
+// BreakerStatusProvider reports the state of a set of circuit breakers.
+type BreakerStatusProvider interface {
+	Statuses() []breaker.Status
+}
+
+type breakerStatusController struct {
+	provider BreakerStatusProvider
+	logger   service.Logger
+}
+
+// NewBreakerStatusController reports the state and in-flight calls of every
+// circuit breaker guarding Redis and the external API clients.
+func NewBreakerStatusController(provider BreakerStatusProvider, logger service.Logger) common.Handler {
+	controller := &breakerStatusController{
+		provider: provider,
+		logger:   logger,
+	}
+
+	return common.NewHandler(map[common.HTTPMethod]echo.HandlerFunc{
+		common.MethodGet: controller.handleGet,
+	})
+}
+
+func (c *breakerStatusController) handleGet(ctx echo.Context) error {
+	statuses := c.provider.Statuses()
+	if statuses == nil {
+		statuses = []breaker.Status{}
+	}
+
+	return ctx.JSON(http.StatusOK, statuses)
+}
@@ -0,0 +1,55 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package admin
+
+import (
+	"net/http"
+
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/service"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/common"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/cache"
+	echo "github.com/labstack/echo/v4"
+)
+
+type cacheStatsController struct {
+	provider cache.StatsProvider
+	logger   service.Logger
+}
+
+// NewCacheStatsController reports lookup statistics of caches that keep
+// them. Other caches respond with 404.
+func NewCacheStatsController(store service.Cache, logger service.Logger) common.Handler {
+	provider, _ := store.(cache.StatsProvider)
+	controller := &cacheStatsController{
+		provider: provider,
+		logger:   logger,
+	}
+
+	return common.NewHandler(map[common.HTTPMethod]echo.HandlerFunc{
+		common.MethodGet: controller.handleGet,
+	})
+}
+
+func (c *cacheStatsController) handleGet(ctx echo.Context) error {
+	if c.provider == nil {
+		c.logger.Debug(ctx.Request().Context(), "Cache statistics requested for a cache without statistics", nil)
+		return ctx.JSON(http.StatusNotFound, common.ErrorResponse{Error: ErrCacheStatsUnavailable.Error()})
+	}
+
+	return ctx.JSON(http.StatusOK, c.provider.Stats())
+}
@@ -0,0 +1,104 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package admin
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/core/component"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/service"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/client/miro"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/common"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/oauth"
+	echo "github.com/labstack/echo/v4"
+)
+
+const (
+	defaultStatusLimit = 100
+	maxStatusLimit     = 1000
+)
+
+type tokenStatusController struct {
+	oauthService oauth.OAuthService[miro.AuthenticationResponse]
+	timeout      time.Duration
+	logger       service.Logger
+}
+
+func NewTokenStatusController(
+	oauthService oauth.OAuthService[miro.AuthenticationResponse],
+	timeout time.Duration,
+	logger service.Logger,
+) common.Handler {
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	controller := &tokenStatusController{
+		oauthService: oauthService,
+		timeout:      timeout,
+		logger:       logger,
+	}
+
+	return common.NewHandler(map[common.HTTPMethod]echo.HandlerFunc{
+		common.MethodGet: controller.handleGet,
+	})
+}
+
+func (c *tokenStatusController) handleGet(ctx echo.Context) error {
+	tctx, cancel := context.WithTimeout(ctx.Request().Context(), c.timeout)
+	defer cancel()
+
+	status := component.AuthenticationStatus(ctx.QueryParam("status"))
+	switch status {
+	case "", component.AuthenticationStatusActive, component.AuthenticationStatusRejected:
+	default:
+		c.logger.Warn(ctx.Request().Context(), "Invalid token status filter", service.Fields{"status": status})
+		return ctx.JSON(http.StatusBadRequest, common.ErrorResponse{Error: ErrInvalidStatusFilter.Error()})
+	}
+
+	limit := defaultStatusLimit
+	if value := ctx.QueryParam("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 {
+			c.logger.Warn(ctx.Request().Context(), "Invalid token status limit", service.Fields{"limit": value})
+			return ctx.JSON(http.StatusBadRequest, common.ErrorResponse{Error: ErrInvalidLimit.Error()})
+		}
+
+		limit = min(parsed, maxStatusLimit)
+	}
+
+	after, err := decodeCursor(ctx.QueryParam("cursor"))
+	if err != nil {
+		c.logger.Warn(ctx.Request().Context(), "Invalid token status cursor", service.Fields{"error": err.Error()})
+		return ctx.JSON(http.StatusBadRequest, common.ErrorResponse{Error: err.Error()})
+	}
+
+	statuses, next, err := c.oauthService.Statuses(tctx, status, limit, after)
+	if err != nil {
+		c.logger.Error(ctx.Request().Context(), "Failed to list token statuses", service.Fields{"error": err.Error()})
+		return ctx.JSON(http.StatusInternalServerError, common.ErrorResponse{Error: err.Error()})
+	}
+
+	return ctx.JSON(http.StatusOK, tokenStatusResponse{
+		Tokens:     statuses,
+		NextCursor: encodeCursor(next),
+	})
+}
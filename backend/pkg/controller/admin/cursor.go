@@ -0,0 +1,70 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package admin
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/core"
+)
+
+// pageCursor is the wire form of core.AuthCursor handed out to API clients.
+type pageCursor struct {
+	ExpiresAt int    `json:"e"`
+	TeamID    string `json:"t"`
+	UserID    string `json:"u"`
+}
+
+func encodeCursor(cursor *core.AuthCursor) string {
+	if cursor == nil {
+		return ""
+	}
+
+	raw, _ := json.Marshal(pageCursor{
+		ExpiresAt: cursor.ExpiresAt,
+		TeamID:    cursor.Key.TeamID,
+		UserID:    cursor.Key.UserID,
+	})
+
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeCursor(value string) (*core.AuthCursor, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var cursor pageCursor
+	if err := json.Unmarshal(raw, &cursor); err != nil || cursor.TeamID == "" || cursor.UserID == "" {
+		return nil, ErrInvalidCursor
+	}
+
+	return &core.AuthCursor{
+		ExpiresAt: cursor.ExpiresAt,
+		Key: core.AuthCompositeKey{
+			TeamID: cursor.TeamID,
+			UserID: cursor.UserID,
+		},
+	}, nil
+}
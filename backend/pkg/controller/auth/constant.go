@@ -17,7 +17,23 @@
  */
 package auth
 
+import "time"
+
 const (
 	miroApplicationBase  = "https://miro.com/app"
 	miroInstallationBase = "https://miro.com/app-install/"
+
+	installationPath  = "/api/oauth/install"
+	defaultLanguage   = "en"
+	stateCookieName   = "asc_miro_oauth_state"
+	stateCookiePath   = "/api/oauth"
+	stateCookieMaxAge = 10 * 60
+
+	webhookSignatureHeader = "X-Webhook-Signature"
+	webhookTimestampHeader = "X-Webhook-Timestamp"
+	webhookSignaturePrefix = "sha256="
+	webhookMaxBodySize     = 64 << 10
+	// webhookTolerance bounds how far a webhook timestamp may be from now,
+	// so that a captured request cannot be replayed later.
+	webhookTolerance = 5 * time.Minute
 )
@@ -19,8 +19,11 @@ package auth
 
 import (
 	"context"
+	"crypto/subtle"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/config"
@@ -34,25 +37,26 @@ import (
 )
 
 type authController struct {
-	installationLocation string
-	oauthClient          oauth.OAuthClient[miro.AuthenticationResponse]
-	oauthService         oauthService.OAuthService[miro.AuthenticationResponse]
-	logger               service.Logger
+	oauthClient  oauth.OAuthClient[miro.AuthenticationResponse]
+	oauthService oauthService.OAuthService[miro.AuthenticationResponse]
+	stateService oauthService.StateService
+	translator   service.TranslationProvider
+	logger       service.Logger
 }
 
 func NewAuthController(
 	config *config.Config,
 	oauthClient oauth.OAuthClient[miro.AuthenticationResponse],
 	oauthService oauthService.OAuthService[miro.AuthenticationResponse],
+	stateService oauthService.StateService,
+	translator service.TranslationProvider,
 	logger service.Logger,
 ) common.Handler {
 	controller := &authController{
-		installationLocation: fmt.Sprintf(
-			"%s?response_type=code&client_id=%s&redirect_uri=%s",
-			miroInstallationBase, config.OAuth.ClientID, config.OAuth.RedirectURI,
-		),
 		oauthClient:  oauthClient,
 		oauthService: oauthService,
+		stateService: stateService,
+		translator:   translator,
 		logger:       logger,
 	}
 
@@ -70,7 +74,8 @@ func (c *authController) extractParams(ctx echo.Context) (authQueryParams, error
 	c.logger.Debug(ctx.Request().Context(), "Extractig auth query params")
 
 	params := authQueryParams{
-		Code: ctx.QueryParam("code"),
+		Code:  ctx.QueryParam("code"),
+		State: ctx.QueryParam("state"),
 	}
 
 	if params.Code == "" {
@@ -82,7 +87,7 @@ func (c *authController) extractParams(ctx echo.Context) (authQueryParams, error
 	return params, nil
 }
 
-func (c *authController) handleError(ctx echo.Context, msg string, err error, args ...any) error {
+func (c *authController) handleError(ctx echo.Context, lang, msg string, err error, args ...any) error {
 	fields := service.Fields{"error": err.Error()}
 	for i := 0; i < len(args); i += 2 {
 		if i+1 < len(args) {
@@ -90,10 +95,62 @@ func (c *authController) handleError(ctx echo.Context, msg string, err error, ar
 		}
 	}
 
+	messageID := "errors.authentication.exchange_failed"
+	if errors.Is(err, oauthService.ErrInvalidState) || errors.Is(err, oauthService.ErrStateNotFound) {
+		messageID = "errors.authentication.invalid_state"
+	}
+
 	c.logger.Error(ctx.Request().Context(), msg, fields)
-	return ctx.Render(http.StatusBadRequest, "exchange", map[string]any{
-		"installationLocation": c.installationLocation,
+	return renderExchangeError(ctx, c.translator, http.StatusBadRequest, lang, messageID)
+}
+
+// renderExchangeError shows the localized installation failure page that
+// lets the user restart the installation.
+func renderExchangeError(ctx echo.Context, translator service.TranslationProvider, status int, lang, messageID string) error {
+	return ctx.Render(status, "exchange", map[string]any{
+		"language":             lang,
+		"title":                translator.Translate(ctx.Request().Context(), lang, "errors.authentication.exchange_failed_title"),
+		"message":              translator.Translate(ctx.Request().Context(), lang, messageID),
+		"tryAgain":             translator.Translate(ctx.Request().Context(), lang, "errors.authentication.try_again"),
+		"installationLocation": installationPath + "?lang=" + url.QueryEscape(lang),
+	})
+}
+
+// consumeState checks that the state returned by Miro was issued to this
+// browser and has not been redeemed yet.
+func (c *authController) consumeState(ctx echo.Context, tctx context.Context, state string) (oauthService.AuthorizationState, error) {
+	cookie, err := ctx.Cookie(stateCookieName)
+	ctx.SetCookie(&http.Cookie{
+		Name:     stateCookieName,
+		Path:     stateCookiePath,
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
 	})
+
+	if err != nil || subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(state)) != 1 {
+		return oauthService.AuthorizationState{}, oauthService.ErrInvalidState
+	}
+
+	return c.stateService.Consume(tctx, state)
+}
+
+// restartInstallation handles installs started from the Miro Marketplace,
+// which redirect back with a code but no state. The code is discarded and
+// the installation restarts through the install endpoint, which issues a
+// state. A browser already holding a state cookie has been through that
+// endpoint, so it is refused instead of being redirected in a loop.
+func (c *authController) restartInstallation(ctx echo.Context, lang string) error {
+	if _, err := ctx.Cookie(stateCookieName); err == nil {
+		return c.handleError(ctx, lang, "Missing authorization state", oauthService.ErrInvalidState)
+	}
+
+	c.logger.Info(ctx.Request().Context(), "Restarting installation without authorization state", service.Fields{
+		"location": installationPath,
+	})
+
+	return ctx.Redirect(http.StatusFound, installationPath+"?lang="+url.QueryEscape(lang))
 }
 
 func (c *authController) handleGet(ctx echo.Context) error {
@@ -108,15 +165,29 @@ func (c *authController) handleGet(ctx echo.Context) error {
 	tctx, cancel := context.WithTimeout(ctx.Request().Context(), 3*time.Second)
 	defer cancel()
 
+	lang := defaultLanguage
 	params, err := c.extractParams(ctx)
 	if err != nil {
-		return c.handleError(ctx, "Failed to extract authorization code", err)
+		return c.handleError(ctx, lang, "Failed to extract authorization code", err)
+	}
+
+	if params.State == "" {
+		return c.restartInstallation(ctx, lang)
+	}
+
+	state, err := c.consumeState(ctx, tctx, params.State)
+	if err != nil {
+		return c.handleError(ctx, lang, "Failed to verify authorization state", err)
+	}
+
+	if state.Language != "" {
+		lang = state.Language
 	}
 
 	c.logger.Info(tctx, "Exchanging authorization code for token")
-	token, err := c.oauthClient.Exchange(tctx, params.Code)
+	token, err := c.oauthClient.ExchangeWithVerifier(tctx, params.Code, state.Verifier)
 	if err != nil {
-		return c.handleError(ctx, "Failed to exchange authorization code", err,
+		return c.handleError(ctx, lang, "Failed to exchange authorization code", err,
 			"code", params.Code)
 	}
 
@@ -145,7 +216,7 @@ func (c *authController) handleGet(ctx echo.Context) error {
 	})
 
 	if err := c.oauthService.Save(tctx, token.TeamID, token.UserID, auth); err != nil {
-		return c.handleError(ctx, "Failed to persist authentication token", err,
+		return c.handleError(ctx, lang, "Failed to persist authentication token", err,
 			"user_id", token.UserID,
 			"team_id", token.TeamID)
 	}
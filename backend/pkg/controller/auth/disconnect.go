@@ -0,0 +1,78 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package auth
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/service"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/client/miro"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/common"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/middleware/authentication"
+	oauthService "github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/oauth"
+	echo "github.com/labstack/echo/v4"
+)
+
+type disconnectController struct {
+	oauthService oauthService.OAuthService[miro.AuthenticationResponse]
+	logger       service.Logger
+}
+
+// NewDisconnectController lets a user revoke and forget their own Miro
+// authorization.
+func NewDisconnectController(
+	oauthService oauthService.OAuthService[miro.AuthenticationResponse],
+	logger service.Logger,
+) common.Handler {
+	controller := &disconnectController{
+		oauthService: oauthService,
+		logger:       logger,
+	}
+
+	return common.NewHandler(map[common.HTTPMethod]echo.HandlerFunc{
+		common.MethodPost: controller.handlePost,
+	})
+}
+
+func (c *disconnectController) handlePost(ctx echo.Context) error {
+	tctx, cancel := context.WithTimeout(ctx.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	token, ok := ctx.Get(common.ContextKeyUser).(*authentication.TokenClaims)
+	if !ok {
+		c.logger.Error(ctx.Request().Context(), "Missing authentication claims on disconnect")
+		return ctx.JSON(http.StatusUnauthorized, common.ErrorResponse{Error: ErrMissingAuthenticationClaims.Error()})
+	}
+
+	if err := c.oauthService.Revoke(tctx, token.Team, token.User); err != nil {
+		c.logger.Error(ctx.Request().Context(), "Failed to disconnect user", service.Fields{
+			"error":   err.Error(),
+			"team_id": token.Team,
+			"user_id": token.User,
+		})
+		return ctx.JSON(http.StatusInternalServerError, common.ErrorResponse{Error: err.Error()})
+	}
+
+	c.logger.Info(ctx.Request().Context(), "User disconnected", service.Fields{
+		"team_id": token.Team,
+		"user_id": token.User,
+	})
+	return ctx.NoContent(http.StatusNoContent)
+}
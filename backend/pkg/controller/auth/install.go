@@ -0,0 +1,100 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/config"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/service"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/common"
+	oauthService "github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/oauth"
+	echo "github.com/labstack/echo/v4"
+)
+
+type installController struct {
+	clientID     string
+	redirectURI  string
+	pkce         bool
+	stateService oauthService.StateService
+	translator   service.TranslationProvider
+	logger       service.Logger
+}
+
+// NewInstallController starts the installation flow by redirecting to Miro
+// with a fresh single-use state and, when enabled, a PKCE code challenge.
+func NewInstallController(
+	config *config.Config,
+	stateService oauthService.StateService,
+	translator service.TranslationProvider,
+	logger service.Logger,
+) common.Handler {
+	controller := &installController{
+		clientID:     config.OAuth.ClientID,
+		redirectURI:  config.OAuth.RedirectURI,
+		pkce:         config.OAuth.PKCE,
+		stateService: stateService,
+		translator:   translator,
+		logger:       logger,
+	}
+
+	return common.NewHandler(map[common.HTTPMethod]echo.HandlerFunc{
+		common.MethodGet: controller.handleGet,
+	})
+}
+
+func (c *installController) handleGet(ctx echo.Context) error {
+	tctx, cancel := context.WithTimeout(ctx.Request().Context(), 3*time.Second)
+	defer cancel()
+
+	lang := ctx.QueryParam("lang")
+	if lang == "" {
+		lang = defaultLanguage
+	}
+
+	state, err := c.stateService.Issue(tctx, lang, c.pkce)
+	if err != nil {
+		c.logger.Error(ctx.Request().Context(), "Failed to issue authorization state", service.Fields{"error": err.Error()})
+		return renderExchangeError(ctx, c.translator, http.StatusInternalServerError, lang, "errors.authentication.exchange_failed")
+	}
+
+	query := url.Values{}
+	query.Set("response_type", "code")
+	query.Set("client_id", c.clientID)
+	query.Set("redirect_uri", c.redirectURI)
+	query.Set("state", state.State)
+	if state.Challenge != "" {
+		query.Set("code_challenge", state.Challenge)
+		query.Set("code_challenge_method", "S256")
+	}
+
+	ctx.SetCookie(&http.Cookie{
+		Name:     stateCookieName,
+		Value:    state.State,
+		Path:     stateCookiePath,
+		MaxAge:   stateCookieMaxAge,
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	return ctx.Redirect(http.StatusFound, miroInstallationBase+"?"+query.Encode())
+}
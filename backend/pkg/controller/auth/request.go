@@ -18,5 +18,11 @@
 package auth
 
 type authQueryParams struct {
-	Code string `query:"code"`
+	Code  string `query:"code"`
+	State string `query:"state"`
+}
+
+type uninstallRequest struct {
+	TeamID string `json:"team_id"`
+	UserID string `json:"user_id,omitempty"`
 }
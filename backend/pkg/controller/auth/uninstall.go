@@ -0,0 +1,154 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/config"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/service"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/client/miro"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/common"
+	oauthService "github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/oauth"
+	settingsService "github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/settings"
+	echo "github.com/labstack/echo/v4"
+)
+
+type uninstallController struct {
+	secret          []byte
+	oauthService    oauthService.OAuthService[miro.AuthenticationResponse]
+	settingsService settingsService.SettingsService
+	logger          service.Logger
+}
+
+// NewUninstallController handles app uninstall notifications. Requests carry
+// a Unix timestamp header and are signed with an HMAC-SHA256 of the timestamp,
+// a dot and the raw body, keyed by the OAuth client secret. Requests with a
+// timestamp outside webhookTolerance are rejected. A payload without user_id
+// removes every token and setting of the team.
+func NewUninstallController(
+	config *config.Config,
+	oauthService oauthService.OAuthService[miro.AuthenticationResponse],
+	settingsService settingsService.SettingsService,
+	logger service.Logger,
+) common.Handler {
+	controller := &uninstallController{
+		secret:          []byte(config.OAuth.ClientSecret),
+		oauthService:    oauthService,
+		settingsService: settingsService,
+		logger:          logger,
+	}
+
+	return common.NewHandler(map[common.HTTPMethod]echo.HandlerFunc{
+		common.MethodPost: controller.handlePost,
+	})
+}
+
+func (c *uninstallController) verifySignature(body []byte, timestamp, header string, now time.Time) bool {
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+
+	if age := now.Sub(time.Unix(seconds, 0)); age > webhookTolerance || age < -webhookTolerance {
+		return false
+	}
+
+	signature, ok := strings.CutPrefix(header, webhookSignaturePrefix)
+	if !ok {
+		return false
+	}
+
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+func (c *uninstallController) handlePost(ctx echo.Context) error {
+	tctx, cancel := context.WithTimeout(ctx.Request().Context(), 20*time.Second)
+	defer cancel()
+
+	body, err := io.ReadAll(io.LimitReader(ctx.Request().Body, webhookMaxBodySize))
+	if err != nil {
+		c.logger.Error(ctx.Request().Context(), "Failed to read uninstall webhook body", service.Fields{"error": err.Error()})
+		return ctx.JSON(http.StatusBadRequest, common.ErrorResponse{Error: ErrInvalidWebhookPayload.Error()})
+	}
+
+	header := ctx.Request().Header
+	if !c.verifySignature(body, header.Get(webhookTimestampHeader), header.Get(webhookSignatureHeader), time.Now()) {
+		c.logger.Warn(ctx.Request().Context(), "Invalid uninstall webhook signature", service.Fields{"ip": ctx.RealIP()})
+		return ctx.JSON(http.StatusUnauthorized, common.ErrorResponse{Error: ErrInvalidWebhookSignature.Error()})
+	}
+
+	var payload uninstallRequest
+	if err := json.Unmarshal(body, &payload); err != nil || payload.TeamID == "" {
+		c.logger.Warn(ctx.Request().Context(), "Invalid uninstall webhook payload", nil)
+		return ctx.JSON(http.StatusBadRequest, common.ErrorResponse{Error: ErrInvalidWebhookPayload.Error()})
+	}
+
+	fields := service.Fields{
+		"team_id": payload.TeamID,
+		"user_id": payload.UserID,
+	}
+
+	if payload.UserID != "" {
+		if err := c.oauthService.Revoke(tctx, payload.TeamID, payload.UserID); err != nil {
+			fields["error"] = err.Error()
+			c.logger.Error(ctx.Request().Context(), "Failed to revoke user on uninstall", fields)
+			return ctx.JSON(http.StatusInternalServerError, common.ErrorResponse{Error: err.Error()})
+		}
+
+		c.logger.Info(ctx.Request().Context(), "User uninstalled the app", fields)
+		return ctx.NoContent(http.StatusNoContent)
+	}
+
+	revoked, err := c.oauthService.RevokeTeam(tctx, payload.TeamID)
+	if err != nil {
+		fields["error"] = err.Error()
+		c.logger.Error(ctx.Request().Context(), "Failed to revoke team tokens on uninstall", fields)
+		return ctx.JSON(http.StatusInternalServerError, common.ErrorResponse{Error: err.Error()})
+	}
+
+	deleted, err := c.settingsService.DeleteTeam(tctx, payload.TeamID)
+	if err != nil {
+		fields["error"] = err.Error()
+		c.logger.Error(ctx.Request().Context(), "Failed to delete team settings on uninstall", fields)
+		return ctx.JSON(http.StatusInternalServerError, common.ErrorResponse{Error: err.Error()})
+	}
+
+	fields["revoked_tokens"] = revoked
+	fields["deleted_settings"] = deleted
+	c.logger.Info(ctx.Request().Context(), "Team uninstalled the app", fields)
+	return ctx.NoContent(http.StatusNoContent)
+}
@@ -21,7 +21,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/config"
@@ -31,6 +33,7 @@ import (
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/client/miro"
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/common"
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/middleware/authentication"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/breaker"
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/document"
 	oauthService "github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/oauth"
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/settings"
@@ -166,12 +169,43 @@ func (c *BaseController) HandleWarning(ctx echo.Context, err error, status int,
 	return nil
 }
 
-func (c *BaseController) withTimeout(duration time.Duration) (context.Context, context.CancelFunc) {
-	return context.WithTimeout(context.Background(), duration)
+// HandleMiroError responds to a failed Miro API call. Unauthorized, forbidden,
+// not found and rate limited responses are passed on with their own status,
+// calls rejected by an open circuit or a full bulkhead are reported as 503 and
+// anything else is reported with the fallback status.
+func (c *BaseController) HandleMiroError(ctx echo.Context, err error, fallback int, message string) error {
+	if errors.Is(err, breaker.ErrCircuitOpen) || errors.Is(err, breaker.ErrBulkheadFull) {
+		return c.HandleWarning(ctx, err, http.StatusServiceUnavailable, message)
+	}
+
+	status, ok := miro.StatusCode(err)
+	if !ok {
+		return c.HandleError(ctx, err, fallback, message)
+	}
+
+	switch status {
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound:
+		return c.HandleWarning(ctx, err, status, message)
+	case http.StatusTooManyRequests:
+		var limited *miro.RateLimitedError
+		if errors.As(err, &limited) && limited.RetryAfter > 0 {
+			seconds := int(math.Ceil(limited.RetryAfter.Seconds()))
+			ctx.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(seconds))
+		}
+		return c.HandleWarning(ctx, err, status, message)
+	default:
+		return c.HandleError(ctx, err, fallback, message)
+	}
+}
+
+// withTimeout derives from the request context so that the request span,
+// trace and log fields reach the storage and upstream calls made by fn.
+func (c *BaseController) withTimeout(ctx echo.Context, duration time.Duration) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx.Request().Context(), duration)
 }
 
 func (c *BaseController) ExecuteWithTimeout(ctx echo.Context, duration time.Duration, fn func(context.Context) error) error {
-	tctx, cancel := c.withTimeout(duration)
+	tctx, cancel := c.withTimeout(ctx, duration)
 	defer cancel()
 
 	return fn(tctx)
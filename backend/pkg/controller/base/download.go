@@ -0,0 +1,89 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package base
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/core/component"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/crypto"
+	jwt "github.com/golang-jwt/jwt/v5"
+)
+
+const downloadTokenLifetime = 10 * time.Minute
+
+type DownloadClaims struct {
+	UID string `json:"uid"`
+	TID string `json:"tid"`
+	BID string `json:"bid"`
+	FID string `json:"fid"`
+	jwt.RegisteredClaims
+}
+
+// ResolveSigningKey returns the secret and algorithm shared with the Document
+// Server the board is currently using, taking an active demo into account.
+func (c *BaseController) ResolveSigningKey(settings *component.Settings) (string, crypto.Algorithm) {
+	if settings.Demo.Enabled && (settings.Address == "" || settings.Secret == "") && settings.Demo.Started != nil &&
+		settings.Demo.Started.Add(time.Duration(c.Config.DemoServer.Days)*24*time.Hour).After(time.Now()) {
+		return c.Config.DemoServer.Secret, crypto.HS256
+	}
+
+	return settings.Secret, crypto.Algorithm(settings.Algorithm)
+}
+
+// ResolveVerificationKeys returns the keys a token signed for the Document
+// Server may be verified with: the signing key and, while a secret rotation
+// is in progress, the previous secret.
+func (c *BaseController) ResolveVerificationKeys(settings *component.Settings) (string, crypto.Algorithm, [][]byte) {
+	secret, algorithm := c.ResolveSigningKey(settings)
+	if secret != settings.Secret || settings.SecondarySecret == "" ||
+		settings.SecondaryExpiresAt == nil || !settings.SecondaryExpiresAt.After(time.Now()) {
+		return secret, algorithm, nil
+	}
+
+	return secret, algorithm, [][]byte{[]byte(settings.SecondarySecret)}
+}
+
+// BuildDownloadURL returns a short-lived backend URL the Document Server can
+// fetch the file from instead of a Miro public URL.
+func (c *BaseController) BuildDownloadURL(uid, tid, bid, fid, secret string, algorithm crypto.Algorithm) (string, error) {
+	now := time.Now()
+	token, err := c.JwtService.CreateWithAlgorithm(DownloadClaims{
+		UID: uid,
+		TID: tid,
+		BID: bid,
+		FID: fid,
+		RegisteredClaims: jwt.RegisteredClaims{
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(downloadTokenLifetime)),
+		},
+	}, []byte(secret), algorithm)
+	if err != nil {
+		return "", err
+	}
+
+	query := url.Values{}
+	query.Set("uid", uid)
+	query.Set("tid", tid)
+	query.Set("bid", bid)
+	query.Set("fid", fid)
+	query.Set("token", token)
+
+	return c.Config.Server.FileDownloadURL() + "?" + query.Encode(), nil
+}
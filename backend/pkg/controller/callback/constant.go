@@ -23,4 +23,12 @@ const (
 	callbackErrorCodeSuccess = "0"
 	callbackErrorCodeFailure = "1"
 	saveFileRequestTimeout   = 4 * time.Second
+	// uploadFileContentRequestTimeout bounds streaming a saved file through the backend.
+	uploadFileContentRequestTimeout = 2 * time.Minute
+)
+
+const (
+	callbackStatusEditing  = 1
+	callbackStatusMustSave = 2
+	callbackStatusClosed   = 4
 )
@@ -29,9 +29,12 @@ import (
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/core/component"
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/crypto"
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/service"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/client/docserver"
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/client/miro"
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/common"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/metrics"
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/oauth"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/registry"
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/settings"
 	echo "github.com/labstack/echo/v4"
 	errgroup "golang.org/x/sync/errgroup"
@@ -40,26 +43,35 @@ import (
 type callbackController struct {
 	config          *config.Config
 	miroClient      miro.Client
+	docServerClient docserver.Client
 	jwtService      crypto.Signer
 	oauthService    oauth.OAuthService[miro.AuthenticationResponse]
 	settingsService settings.SettingsService
+	keyRegistry     registry.KeyRegistry
+	metrics         *metrics.Metrics
 	logger          service.Logger
 }
 
 func NewCallbackController(
 	config *config.Config,
 	miroClient miro.Client,
+	docServerClient docserver.Client,
 	jwtService crypto.Signer,
 	oauthService oauth.OAuthService[miro.AuthenticationResponse],
 	settingsService settings.SettingsService,
+	keyRegistry registry.KeyRegistry,
+	metrics *metrics.Metrics,
 	logger service.Logger,
 ) common.Handler {
 	controller := &callbackController{
 		config:          config,
 		miroClient:      miroClient,
+		docServerClient: docServerClient,
 		jwtService:      jwtService,
 		oauthService:    oauthService,
 		settingsService: settingsService,
+		keyRegistry:     keyRegistry,
+		metrics:         metrics,
 		logger:          logger,
 	}
 
@@ -132,13 +144,111 @@ func (c *callbackController) fetchAuthenticationAndSettings(
 	return auth, settings, nil
 }
 
-func (c *callbackController) getSecretFromSettings(settings component.Settings) string {
+func (c *callbackController) getSecretsFromSettings(settings component.Settings) (string, crypto.Algorithm, [][]byte) {
 	if settings.Demo.Enabled &&
 		settings.Address == "" &&
 		settings.Demo.Started.Add(time.Duration(c.config.DemoServer.Days)*24*time.Hour).After(time.Now()) {
-		return c.config.DemoServer.Secret
+		return c.config.DemoServer.Secret, crypto.HS256, nil
 	}
-	return settings.Secret
+
+	algorithm := crypto.Algorithm(settings.Algorithm)
+	if settings.SecondarySecret != "" && settings.SecondaryExpiresAt != nil && settings.SecondaryExpiresAt.After(time.Now()) {
+		return settings.Secret, algorithm, [][]byte{[]byte(settings.SecondarySecret)}
+	}
+
+	return settings.Secret, algorithm, nil
+}
+
+// uploadMode names the way a saved file is uploaded to Miro.
+func uploadMode(settings component.Settings) string {
+	if settings.UploadMode == component.UploadModeContent {
+		return string(component.UploadModeContent)
+	}
+
+	return string(component.UploadModeURL)
+}
+
+func (c *callbackController) uploadFile(
+	ctx context.Context,
+	params callbackQueryParams,
+	settings component.Settings,
+	auth component.Authentication,
+	fileURL string,
+) error {
+	if settings.UploadMode != component.UploadModeContent {
+		uctx, cancel := context.WithTimeout(ctx, saveFileRequestTimeout)
+		defer cancel()
+
+		_, err := c.miroClient.UploadFile(uctx, miro.UploadFileRequest{
+			BoardID:  params.BID,
+			ItemID:   params.FID,
+			Filename: params.Filename,
+			FileURL:  docserver.RewriteAddress(fileURL, settings.InternalAddress, settings.Address),
+			Token:    auth.AccessToken,
+		})
+
+		return err
+	}
+
+	uctx, cancel := context.WithTimeout(ctx, uploadFileContentRequestTimeout)
+	defer cancel()
+
+	// The backend may only reach the Document Server on its internal address,
+	// while the callback carries URLs on the public one.
+	file, err := c.docServerClient.DownloadFile(uctx, docserver.RewriteAddress(fileURL, settings.Address, settings.InternalAddress),
+		docserver.WithTLSConfig(docserver.NewTLSConfig(settings.TLS)))
+	if err != nil {
+		return fmt.Errorf("failed to download file from docserver: %w", err)
+	}
+
+	defer file.Body.Close()
+
+	_, err = c.miroClient.UploadFileContent(uctx, miro.UploadFileContentRequest{
+		BoardID:  params.BID,
+		ItemID:   params.FID,
+		Filename: params.Filename,
+		Content:  file.Body,
+		Token:    auth.AccessToken,
+	})
+
+	return err
+}
+
+// handleSessionStatus keeps the document key pinned while an editing session
+// is open and releases it once every participant has left without changes.
+func (c *callbackController) handleSessionStatus(ctx echo.Context, tctx context.Context, params callbackQueryParams, body callbackRequest) error {
+	settings, err := c.settingsService.Find(tctx, params.TID, params.BID)
+	if err != nil {
+		return c.logErrorAndRespond(ctx, http.StatusBadRequest, "Failed to fetch settings", err)
+	}
+
+	secret, algorithm, fallbacks := c.getSecretsFromSettings(settings)
+	if err := c.jwtService.ValidateTarget(body.Token, []byte(secret), algorithm, &body, fallbacks...); err != nil {
+		return c.logErrorAndRespond(ctx, http.StatusUnauthorized, "Failed to validate and map token", err)
+	}
+
+	c.metrics.ObserveCallback(body.Status)
+
+	fields := service.Fields{
+		"status": body.Status,
+		"bid":    params.BID,
+		"fid":    params.FID,
+	}
+
+	if body.Status == callbackStatusEditing {
+		err = c.keyRegistry.Pin(tctx, params.BID, params.FID, body.Key)
+	} else {
+		err = c.keyRegistry.Release(tctx, params.BID, params.FID)
+	}
+
+	if err != nil {
+		fields["error"] = err.Error()
+		c.logger.Warn(ctx.Request().Context(), "Failed to update document key registry", fields)
+	} else {
+		c.logger.Debug(ctx.Request().Context(), "Document key registry updated", fields)
+	}
+
+	return ctx.JSON(http.StatusOK, common.ErrorResponse{Error: callbackErrorCodeSuccess})
 }
 
 func (c *callbackController) handlePost(ctx echo.Context) error {
@@ -159,7 +269,11 @@ func (c *callbackController) handlePost(ctx echo.Context) error {
 		return c.logErrorAndRespond(ctx, http.StatusBadRequest, "Failed to validate request body", err)
 	}
 
-	if body.Status == 2 {
+	if body.Status == callbackStatusEditing || body.Status == callbackStatusClosed {
+		return c.handleSessionStatus(ctx, tctx, params, body)
+	}
+
+	if body.Status == callbackStatusMustSave {
 		c.logger.Debug(ctx.Request().Context(), "Processing callback with save status", nil)
 		if body.Token == "" {
 			c.logger.Error(ctx.Request().Context(), "Failed to extract token from request body", nil)
@@ -173,28 +287,28 @@ func (c *callbackController) handlePost(ctx echo.Context) error {
 
 		c.logger.Debug(ctx.Request().Context(), "Successfully fetched authentication and settings", nil)
 
-		secret := c.getSecretFromSettings(settings)
+		secret, algorithm, fallbacks := c.getSecretsFromSettings(settings)
 		c.logger.Debug(ctx.Request().Context(), "Validating token", nil)
-		if err = c.jwtService.ValidateTarget(body.Token, []byte(secret), &body); err != nil {
+		if err = c.jwtService.ValidateTarget(body.Token, []byte(secret), algorithm, &body, fallbacks...); err != nil {
 			return c.logErrorAndRespond(ctx, http.StatusUnauthorized, "Failed to validate and map token", err)
 		}
 
 		c.logger.Debug(ctx.Request().Context(), "Token validated successfully", nil)
+		c.metrics.ObserveCallback(body.Status)
 
 		c.logger.Info(ctx.Request().Context(), "Uploading file to Miro", service.Fields{
-			"board_id": params.BID,
-			"file_id":  params.FID,
-			"file_url": body.Url,
-			"filename": params.Filename,
+			"board_id":    params.BID,
+			"file_id":     params.FID,
+			"file_url":    body.Url,
+			"filename":    params.Filename,
+			"upload_mode": settings.UploadMode,
 		})
 
-		if _, err := c.miroClient.UploadFile(tctx, miro.UploadFileRequest{
-			BoardID:  params.BID,
-			ItemID:   params.FID,
-			Filename: params.Filename,
-			FileURL:  body.Url,
-			Token:    auth.AccessToken,
-		}); err != nil {
+		// The upload is detached from the Document Server connection so that
+		// a save already streaming completes within its own deadline.
+		err = c.uploadFile(context.WithoutCancel(ctx.Request().Context()), params, settings, auth, body.Url)
+		c.metrics.ObserveUpload(uploadMode(settings), err)
+		if err != nil {
 			c.logger.Error(ctx.Request().Context(), "Failed to upload file",
 				service.Fields{
 					"error":    err.Error(),
@@ -213,6 +327,14 @@ func (c *callbackController) handlePost(ctx echo.Context) error {
 				"filename": params.Filename,
 			},
 		)
+
+		if err := c.keyRegistry.Release(ctx.Request().Context(), params.BID, params.FID); err != nil {
+			c.logger.Warn(ctx.Request().Context(), "Failed to release document key", service.Fields{
+				"error":    err.Error(),
+				"board_id": params.BID,
+				"file_id":  params.FID,
+			})
+		}
 	} else {
 		c.logger.Info(ctx.Request().Context(), "Skipping file upload for non-save callback",
 			service.Fields{
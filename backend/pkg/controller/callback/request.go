@@ -27,6 +27,7 @@ type callbackQueryParams struct {
 
 type callbackRequest struct {
 	Status int    `json:"status"`
+	Key    string `json:"key,omitempty"`
 	Url    string `json:"url,omitempty"`
 	Token  string `json:"token,omitempty"`
 }
@@ -35,6 +35,7 @@ import (
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/controller/base"
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/document"
 	oauthService "github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/oauth"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/registry"
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/settings"
 	echo "github.com/labstack/echo/v4"
 	errgroup "golang.org/x/sync/errgroup"
@@ -42,6 +43,7 @@ import (
 
 type editorController struct {
 	base.BaseController
+	keyRegistry registry.KeyRegistry
 }
 
 func NewEditorController(
@@ -51,6 +53,7 @@ func NewEditorController(
 	builderService document.BuilderService,
 	oauthService oauthService.OAuthService[miro.AuthenticationResponse],
 	settingsService settings.SettingsService,
+	keyRegistry registry.KeyRegistry,
 	translationService service.TranslationProvider,
 	logger service.Logger,
 ) common.Handler {
@@ -65,6 +68,7 @@ func NewEditorController(
 			translationService,
 			logger,
 		),
+		keyRegistry: keyRegistry,
 	}
 
 	return common.NewHandler(map[common.HTTPMethod]echo.HandlerFunc{
@@ -153,22 +157,13 @@ func (c *editorController) fetchMiroData(
 		return nil, nil, nil, err
 	}
 
-	publicFile, err := c.BaseController.MiroClient.GetFilePublicURL(ctx, miro.GetFilePublicURLRequest{
-		URL:   fileInfo.Data.DocumentURL,
-		Token: accessToken,
-	})
-
-	if err != nil {
-		return nil, nil, nil, fmt.Errorf("failed to get public URL: %w", err)
-	}
-
-	fileInfo.Data.DocumentURL = publicFile.URL
 	return userInfo, boardInfo, fileInfo, nil
 }
 
-func (c *editorController) resolveServerSettings(settings *component.Settings) (address, secret string, err error) {
+func (c *editorController) resolveServerSettings(settings *component.Settings) (address, secret string, algorithm crypto.Algorithm, err error) {
 	address = settings.Address
 	secret = settings.Secret
+	algorithm = crypto.Algorithm(settings.Algorithm)
 
 	if settings.Demo.Enabled && address == "" && secret == "" {
 		if settings.Demo.Started != nil {
@@ -176,15 +171,16 @@ func (c *editorController) resolveServerSettings(settings *component.Settings) (
 			if demoExpiry.After(time.Now()) {
 				address = c.BaseController.Config.DemoServer.Address
 				secret = c.BaseController.Config.DemoServer.Secret
+				algorithm = crypto.HS256
 			}
 		}
 	}
 
 	if address == "" || secret == "" {
-		return "", "", base.ErrSettingsNotConfigured
+		return "", "", "", base.ErrSettingsNotConfigured
 	}
 
-	return address, secret, nil
+	return address, secret, algorithm, nil
 }
 
 func (c *editorController) buildEditorConfig(
@@ -194,12 +190,16 @@ func (c *editorController) buildEditorConfig(
 	user *miro.BoardMemberResponse,
 	file *miro.FileInfoResponse,
 	secret string,
+	algorithm crypto.Algorithm,
+	documentKey string,
 ) (*document.Config, error) {
 	config, err := c.BaseController.BuilderService.Build(
 		ctx,
 		callbackURL,
 		builderRequest{Board: boardID, File: *file},
 		document.WithKey([]byte(secret)),
+		document.WithAlgorithm(algorithm),
+		document.WithDocumentKey(documentKey),
 		document.WithUserConfigurer(user),
 	)
 
@@ -237,7 +237,7 @@ func (c *editorController) handleGet(ctx echo.Context) error {
 			return err
 		}
 
-		address, secret, err := c.resolveServerSettings(settings)
+		address, secret, algorithm, err := c.resolveServerSettings(settings)
 		if err := handleRequestError(err, c.BaseController.TranslationService.Translate(tctx, params.lang, "editor.errors.invalid_configuration")); err != nil {
 			return err
 		}
@@ -247,13 +247,28 @@ func (c *editorController) handleGet(ctx echo.Context) error {
 			return err
 		}
 
+		downloadURL, err := c.BaseController.BuildDownloadURL(params.uid, params.tid, params.bid, params.fid, secret, algorithm)
+		if err := handleRequestError(err, c.BaseController.TranslationService.Translate(tctx, params.lang, "editor.errors.build_editor_configuration")); err != nil {
+			return err
+		}
+
+		file.Data.DocumentURL = downloadURL
+		documentKey, err := c.keyRegistry.Find(tctx, params.bid, params.fid)
+		if err != nil {
+			c.BaseController.Logger.Warn(tctx, "Failed to look up pinned document key", service.Fields{
+				"board_id": params.bid,
+				"file_id":  params.fid,
+				"error":    err.Error(),
+			})
+		}
+
 		callbackURL := buildCallbackURL(c.BaseController.Config.Server.CallbackURL, params.fid, params.uid, params.tid, params.bid, file.Data.Title)
 		config, err := c.buildEditorConfig(tctx, callbackURL, board.ID, &miro.BoardMemberResponse{
 			MemberID:   uinfo.User.ID,
 			MemberName: uinfo.User.Name,
 			Role:       "member",
 			Lang:       params.lang,
-		}, file, secret)
+		}, file, secret, algorithm, documentKey)
 		if err := handleRequestError(err, c.BaseController.TranslationService.Translate(tctx, params.lang, "editor.errors.build_editor_configuration")); err != nil {
 			return err
 		}
@@ -91,19 +91,10 @@ func (c *fileConversionController) handleGet(ctx echo.Context) error {
 
 		if fid, ferr := c.BaseController.GetQueryParam(ctx, "fid"); ferr == nil {
 			file, err := GetFileInfo(ctx, tctx, &c.BaseController, boardAuth.BoardID, fid, boardAuth.Authentication.AccessToken)
-			if err != nil {
+			if err != nil || file == nil {
 				return err
 			}
 
-			location, err := c.BaseController.MiroClient.GetFilePublicURL(tctx, miro.GetFilePublicURLRequest{
-				URL:   file.Data.DocumentURL,
-				Token: boardAuth.Authentication.AccessToken,
-			})
-
-			if err != nil {
-				return c.BaseController.HandleError(ctx, err, http.StatusInternalServerError, ErrFailedToFetchMiroFile.Error())
-			}
-
 			token, err := c.BaseController.ExtractUserToken(ctx)
 			if err != nil {
 				return c.BaseController.HandleError(ctx, err, http.StatusForbidden, ErrFailedToExtractToken.Error())
@@ -115,14 +106,25 @@ func (c *fileConversionController) handleGet(ctx echo.Context) error {
 			}
 
 			address := settings.Address
+			internalAddress := settings.InternalAddress
 			header := settings.Header
 			secret := settings.Secret
+			algorithm := crypto.Algorithm(settings.Algorithm)
+			tlsConfig := docserver.NewTLSConfig(settings.TLS)
 			if settings.Demo.Enabled && settings.Demo.Started.Add(time.Duration(c.BaseController.Config.DemoServer.Days)*24*time.Hour).After(time.Now()) && (address == "" || secret == "") {
 				address = c.BaseController.Config.DemoServer.Address
+				internalAddress = ""
+				tlsConfig = docserver.TLSConfig{}
+				algorithm = crypto.HS256
 				header = c.BaseController.Config.DemoServer.Header
 				secret = c.BaseController.Config.DemoServer.Secret
 			}
 
+			downloadURL, err := c.BaseController.BuildDownloadURL(token.User, token.Team, boardAuth.BoardID, fid, secret, algorithm)
+			if err != nil {
+				return c.BaseController.HandleError(ctx, err, http.StatusInternalServerError, "failed to create download url")
+			}
+
 			fileExt := path.Ext(file.Data.Title)
 			convReq := convertClaims{
 				Async:      true,
@@ -130,24 +132,30 @@ func (c *fileConversionController) handleGet(ctx echo.Context) error {
 				Key:        fmt.Sprintf("%x", md5.Sum([]byte(file.Data.DocumentURL))),
 				OutputType: "pdf",
 				Title:      file.Data.Title,
-				URL:        location.URL,
+				URL:        downloadURL,
 				RegisteredClaims: jwt.RegisteredClaims{
 					IssuedAt:  jwt.NewNumericDate(time.Now()),
 					ExpiresAt: jwt.NewNumericDate(time.Now().Add(5 * time.Minute)),
 				},
 			}
 
-			jwtToken, err := c.BaseController.JwtService.Create(convReq, []byte(secret))
+			jwtToken, err := c.BaseController.JwtService.CreateWithAlgorithm(convReq, []byte(secret), algorithm)
 			if err != nil {
 				return c.BaseController.HandleError(ctx, err, http.StatusBadRequest, "failed to create token")
 			}
 
+			if internalAddress == "" {
+				internalAddress = address
+			}
+
 			response, err := c.DocserverClient.ConvertFile(
 				tctx,
-				address,
+				internalAddress,
 				jwtToken,
 				docserver.WithHeader(header),
 				docserver.WithToken(secret),
+				docserver.WithPublicAddress(address),
+				docserver.WithTLSConfig(tlsConfig),
 			)
 
 			if err != nil {
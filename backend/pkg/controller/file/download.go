@@ -0,0 +1,154 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package file
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/config"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/crypto"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/service"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/client/miro"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/common"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/controller/base"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/document"
+	oauthService "github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/oauth"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/settings"
+	echo "github.com/labstack/echo/v4"
+)
+
+const downloadRequestTimeout = 2 * time.Minute
+
+type fileDownloadController struct {
+	base.BaseController
+}
+
+func NewFileDownloadController(
+	config *config.Config,
+	miroClient miro.Client,
+	jwtService crypto.Signer,
+	builderService document.BuilderService,
+	oauthService oauthService.OAuthService[miro.AuthenticationResponse],
+	settingsService settings.SettingsService,
+	translationService service.TranslationProvider,
+	logger service.Logger,
+) common.Handler {
+	controller := &fileDownloadController{
+		BaseController: *base.NewBaseController(
+			config,
+			miroClient,
+			jwtService,
+			builderService,
+			oauthService,
+			settingsService,
+			translationService,
+			logger,
+		),
+	}
+
+	return common.NewHandler(map[common.HTTPMethod]echo.HandlerFunc{
+		common.MethodGet: controller.handleGet,
+	})
+}
+
+func (c *fileDownloadController) extractParams(ctx echo.Context) (base.DownloadClaims, error) {
+	claims := base.DownloadClaims{
+		UID: ctx.QueryParam("uid"),
+		TID: ctx.QueryParam("tid"),
+		BID: ctx.QueryParam("bid"),
+		FID: ctx.QueryParam("fid"),
+	}
+
+	if claims.UID == "" || claims.TID == "" || claims.BID == "" || claims.FID == "" || ctx.QueryParam("token") == "" {
+		return claims, ErrMissingDownloadParameters
+	}
+
+	return claims, nil
+}
+
+func (c *fileDownloadController) handleGet(ctx echo.Context) error {
+	tctx, cancel := context.WithTimeout(ctx.Request().Context(), downloadRequestTimeout)
+	defer cancel()
+
+	params, err := c.extractParams(ctx)
+	if err != nil {
+		return c.BaseController.HandleError(ctx, err, http.StatusBadRequest, "failed to extract download parameters")
+	}
+
+	settings, auth, err := c.BaseController.FetchAuthenticationWithSettings(tctx, params.UID, params.TID, params.BID)
+	if err != nil {
+		if errors.Is(err, base.ErrMissingAuthentication) {
+			return c.BaseController.HandleWarning(ctx, err, http.StatusUnauthorized, "could not retrieve authentication")
+		}
+
+		return c.BaseController.HandleError(ctx, err, http.StatusBadRequest, "could not retrieve required data")
+	}
+
+	secret, algorithm, fallbacks := c.BaseController.ResolveVerificationKeys(settings)
+
+	var claims base.DownloadClaims
+	if err := c.BaseController.JwtService.ValidateTarget(ctx.QueryParam("token"), []byte(secret), algorithm, &claims, fallbacks...); err != nil {
+		return c.BaseController.HandleWarning(ctx, err, http.StatusForbidden, ErrInvalidDownloadToken.Error())
+	}
+
+	if claims.UID != params.UID || claims.TID != params.TID || claims.BID != params.BID || claims.FID != params.FID {
+		return c.BaseController.HandleWarning(ctx, ErrInvalidDownloadToken, http.StatusForbidden, ErrInvalidDownloadToken.Error())
+	}
+
+	file, err := GetFileInfo(ctx, tctx, &c.BaseController, params.BID, params.FID, auth.AccessToken)
+	if err != nil || file == nil {
+		return err
+	}
+
+	location, err := c.BaseController.MiroClient.GetFilePublicURL(tctx, miro.GetFilePublicURLRequest{
+		URL:   file.Data.DocumentURL,
+		Token: auth.AccessToken,
+	})
+	if err != nil {
+		return c.BaseController.HandleMiroError(ctx, err, http.StatusBadGateway, ErrFailedToFetchMiroFile.Error())
+	}
+
+	download, err := c.BaseController.MiroClient.DownloadFile(tctx, miro.DownloadFileRequest{
+		URL: location.URL,
+	})
+	if err != nil {
+		return c.BaseController.HandleMiroError(ctx, err, http.StatusBadGateway, ErrFailedToFetchMiroFile.Error())
+	}
+
+	defer download.Body.Close()
+
+	contentType := download.ContentType
+	if contentType == "" {
+		contentType = echo.MIMEOctetStream
+	}
+
+	if download.ContentLength >= 0 {
+		ctx.Response().Header().Set(echo.HeaderContentLength, strconv.FormatInt(download.ContentLength, 10))
+	}
+
+	c.BaseController.Logger.Debug(ctx.Request().Context(), "Streaming file to document server", service.Fields{
+		"board_id": params.BID,
+		"file_id":  params.FID,
+	})
+
+	return ctx.Stream(http.StatusOK, contentType, download.Body)
+}
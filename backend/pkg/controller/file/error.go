@@ -25,4 +25,6 @@ var (
 	ErrFailedToFetchMiroFile      = errors.New("failed to fetch miro file")
 	ErrFailedToExtractToken       = errors.New("failed to extract token")
 	ErrFailedToFetchSettings      = errors.New("failed to fetch settings")
+	ErrMissingDownloadParameters  = errors.New("missing download parameters")
+	ErrInvalidDownloadToken       = errors.New("invalid download token")
 )
@@ -71,13 +71,13 @@ func NewFileManagementController(
 func (c *fileManagementController) handleGet(ctx echo.Context) error {
 	return c.BaseController.ExecuteWithTimeout(ctx, 4*time.Second, func(tctx context.Context) error {
 		boardAuth, err := PrepareRequest(ctx, tctx, &c.BaseController)
-		if err != nil {
+		if err != nil || boardAuth == nil {
 			return err
 		}
 
 		if fid, ferr := c.BaseController.GetQueryParam(ctx, "fid"); ferr == nil {
 			file, err := GetFileInfo(ctx, tctx, &c.BaseController, boardAuth.BoardID, fid, boardAuth.Authentication.AccessToken)
-			if err != nil {
+			if err != nil || file == nil {
 				return err
 			}
 			return ctx.JSON(200, file)
@@ -89,7 +89,7 @@ func (c *fileManagementController) handleGet(ctx echo.Context) error {
 		}
 
 		files, err := GetFilesInfo(ctx, tctx, &c.BaseController, boardAuth.BoardID, cursor, boardAuth.Authentication.AccessToken)
-		if err != nil {
+		if err != nil || files == nil {
 			return err
 		}
 
@@ -123,7 +123,7 @@ func (c *fileManagementController) handlePost(ctx echo.Context) error {
 		}
 
 		response, err := CreateFile(ctx, tctx, &c.BaseController, req)
-		if err != nil {
+		if err != nil || response == nil {
 			return err
 		}
 
@@ -20,15 +20,16 @@ package file
 import (
 	"context"
 	"errors"
-	"fmt"
 	"net/http"
-	"strings"
 
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/client/miro"
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/controller/base"
 	echo "github.com/labstack/echo/v4"
 )
 
+// The helpers below write the error response themselves. A nil result with
+// a nil error means the response has already been written.
+
 func PrepareRequest(
 	ctx echo.Context,
 	tctx context.Context,
@@ -71,7 +72,7 @@ func CreateFile(
 ) (*miro.FileCreatedResponse, error) {
 	response, err := c.MiroClient.CreateFile(tctx, req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create a file: %w", err)
+		return nil, c.HandleMiroError(ctx, err, http.StatusInternalServerError, "failed to create a file")
 	}
 
 	return response, nil
@@ -92,11 +93,7 @@ func GetFileInfo(
 	})
 
 	if err != nil {
-		if strings.Contains(err.Error(), "status 401") {
-			return nil, c.HandleError(ctx, err, http.StatusUnauthorized, "failed to fetch miro files")
-		}
-
-		return nil, c.HandleError(ctx, err, http.StatusBadRequest, "failed to fetch miro file")
+		return nil, c.HandleMiroError(ctx, err, http.StatusBadRequest, "failed to fetch miro file")
 	}
 
 	return file, nil
@@ -110,7 +107,6 @@ func GetFilesInfo(
 	cursor string,
 	accessToken string,
 ) (*miro.FilesInfoResponse, error) {
-	// TODO (v1.1): Refactor client to extract codes properly
 	files, err := c.MiroClient.GetFilesInfo(tctx, miro.GetFilesInfoRequest{
 		Cursor:  cursor,
 		BoardID: boardID,
@@ -118,11 +114,7 @@ func GetFilesInfo(
 	})
 
 	if err != nil {
-		if strings.Contains(err.Error(), "status 401") {
-			return nil, c.HandleError(ctx, err, http.StatusUnauthorized, "failed to fetch miro files")
-		}
-
-		return nil, c.HandleError(ctx, err, http.StatusBadRequest, "failed to fetch miro files")
+		return nil, c.HandleMiroError(ctx, err, http.StatusBadRequest, "failed to fetch miro files")
 	}
 
 	return files, nil
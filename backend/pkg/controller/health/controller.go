@@ -0,0 +1,61 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package health
+
+import (
+	"net/http"
+
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/common"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/health"
+	echo "github.com/labstack/echo/v4"
+)
+
+// NewLivenessController reports that the process is up and serving requests.
+// It never checks dependencies, so an outage of one never restarts the service.
+func NewLivenessController() common.Handler {
+	return common.NewHandler(map[common.HTTPMethod]echo.HandlerFunc{
+		common.MethodGet: func(ctx echo.Context) error {
+			return ctx.JSON(http.StatusOK, map[string]string{"status": health.StatusUp})
+		},
+	})
+}
+
+type readinessController struct {
+	checker *health.Checker
+}
+
+// NewReadinessController reports the status of every dependency and responds
+// with 503 while a critical one is down.
+func NewReadinessController(checker *health.Checker) common.Handler {
+	controller := &readinessController{
+		checker: checker,
+	}
+
+	return common.NewHandler(map[common.HTTPMethod]echo.HandlerFunc{
+		common.MethodGet: controller.handleGet,
+	})
+}
+
+func (c *readinessController) handleGet(ctx echo.Context) error {
+	report := c.checker.Check(ctx.Request().Context())
+	if !report.Ready() {
+		return ctx.JSON(http.StatusServiceUnavailable, report)
+	}
+
+	return ctx.JSON(http.StatusOK, report)
+}
@@ -67,6 +67,19 @@ func NewSettingsController(
 	})
 }
 
+// boardMemberErrorStatus returns the status to answer with when a board
+// member lookup failed because of the caller's Miro token or rate limit.
+func boardMemberErrorStatus(err error) (int, bool) {
+	switch {
+	case miro.IsUnauthorized(err):
+		return http.StatusUnauthorized, true
+	case miro.IsRateLimited(err):
+		return http.StatusTooManyRequests, true
+	default:
+		return 0, false
+	}
+}
+
 func validateRequest(ctx echo.Context) (string, *authentication.TokenClaims, error) {
 	bid := ctx.QueryParam("bid")
 	if bid == "" {
@@ -128,6 +141,10 @@ func (c *settingsController) handleGet(ctx echo.Context) error {
 
 	if err != nil {
 		c.logger.Warn(ctx.Request().Context(), "Failed to get board member", service.Fields{"error": err, "board_id": bid, "user_id": token.User})
+		if status, ok := boardMemberErrorStatus(err); ok {
+			return ctx.JSON(status, common.ErrorResponse{Error: http.StatusText(status)})
+		}
+
 		return ctx.JSON(http.StatusForbidden, common.ErrorResponse{Error: "Only board members can access this endpoint"})
 	}
 
@@ -142,8 +159,9 @@ func (c *settingsController) handleGet(ctx echo.Context) error {
 		return ctx.JSON(http.StatusBadRequest, common.ErrorResponse{Error: err.Error()})
 	}
 
+	settings.SecondarySecret = ""
 	c.logger.Info(ctx.Request().Context(), "Settings retrieved successfully", service.Fields{"board_id": bid, "user_id": token.User, "team_id": token.Team})
-	return ctx.JSON(http.StatusOK, settings)
+	return ctx.JSON(http.StatusOK, newSettingsResponse(settings))
 }
 
 func (c *settingsController) handlePost(ctx echo.Context) error {
@@ -175,6 +193,14 @@ func (c *settingsController) handlePost(ctx echo.Context) error {
 
 	if err != nil {
 		c.logger.Error(ctx.Request().Context(), "Failed to get board member", service.Fields{"error": err, "board_id": body.BoardID, "user_id": token.User})
+		if status, ok := boardMemberErrorStatus(err); ok {
+			return ctx.JSON(status, common.ErrorResponse{Error: http.StatusText(status)})
+		}
+
+		if miro.IsForbidden(err) || miro.IsNotFound(err) {
+			return ctx.JSON(http.StatusForbidden, common.ErrorResponse{Error: "Only board members can access this endpoint"})
+		}
+
 		return ctx.JSON(http.StatusInternalServerError, common.ErrorResponse{Error: err.Error()})
 	}
 
@@ -188,8 +214,12 @@ func (c *settingsController) handlePost(ctx echo.Context) error {
 		token.Team,
 		body.BoardID,
 		settings.WithAddress(body.Address),
+		settings.WithInternalAddress(body.InternalAddress),
 		settings.WithHeader(body.Header),
 		settings.WithSecret(body.Secret),
+		settings.WithAlgorithm(body.Algorithm),
+		settings.WithUploadMode(body.UploadMode),
+		settings.WithTLS(body.TLS),
 		settings.WithDemo(body.Demo),
 	); err != nil {
 		c.logger.Error(ctx.Request().Context(), "Failed to save settings", service.Fields{"error": err, "board_id": body.BoardID, "team_id": token.Team})
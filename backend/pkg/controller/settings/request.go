@@ -17,12 +17,18 @@
  */
 package settings
 
+import "github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/core/component"
+
 type settingsRequest struct {
-	BoardID string `json:"board_id"`
-	Address string `json:"address"`
-	Header  string `json:"header"`
-	Secret  string `json:"secret"`
-	Demo    bool   `json:"demo"`
+	BoardID         string               `json:"board_id"`
+	Address         string               `json:"address"`
+	InternalAddress string               `json:"internal_address"`
+	Header          string               `json:"header"`
+	Secret          string               `json:"secret"`
+	Algorithm       string               `json:"algorithm"`
+	UploadMode      component.UploadMode `json:"upload_mode"`
+	TLS             component.TLS        `json:"tls"`
+	Demo            bool                 `json:"demo"`
 }
 
 func (r *settingsRequest) Validate() error {
@@ -34,11 +40,15 @@ func (r *settingsRequest) Validate() error {
 }
 
 type persistSettingsRequest struct {
-	BoardID string `json:"board_id"`
-	Address string `json:"address"`
-	Header  string `json:"header"`
-	Secret  string `json:"secret"`
-	Demo    bool   `json:"demo"`
+	BoardID         string               `json:"board_id"`
+	Address         string               `json:"address"`
+	InternalAddress string               `json:"internal_address"`
+	Header          string               `json:"header"`
+	Secret          string               `json:"secret"`
+	Algorithm       string               `json:"algorithm"`
+	UploadMode      component.UploadMode `json:"upload_mode"`
+	TLS             component.TLS        `json:"tls"`
+	Demo            bool                 `json:"demo"`
 }
 
 func (r *persistSettingsRequest) Validate() error {
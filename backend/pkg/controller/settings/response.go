@@ -0,0 +1,48 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package settings
+
+import "github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/core/component"
+
+// settingsResponse shadows the stored TLS settings so that the client key
+// never leaves the backend.
+type settingsResponse struct {
+	component.Settings
+	TLS tlsResponse `json:"tls"`
+}
+
+type tlsResponse struct {
+	CABundle           string   `json:"ca_bundle,omitempty"`
+	ClientCertificate  string   `json:"client_certificate,omitempty"`
+	HasClientKey       bool     `json:"has_client_key"`
+	PinnedCertificates []string `json:"pinned_certificates,omitempty"`
+	InsecureSkipVerify bool     `json:"insecure_skip_verify,omitempty"`
+}
+
+func newSettingsResponse(settings component.Settings) settingsResponse {
+	return settingsResponse{
+		Settings: settings,
+		TLS: tlsResponse{
+			CABundle:           settings.TLS.CABundle,
+			ClientCertificate:  settings.TLS.ClientCertificate,
+			HasClientKey:       settings.TLS.ClientKey != "",
+			PinnedCertificates: settings.TLS.PinnedCertificates,
+			InsecureSkipVerify: settings.TLS.InsecureSkipVerify,
+		},
+	}
+}
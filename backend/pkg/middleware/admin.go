@@ -0,0 +1,57 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/config"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/service"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/common"
+	echo "github.com/labstack/echo/v4"
+)
+
+type AdminMiddleware struct {
+	token  []byte
+	logger service.Logger
+}
+
+func NewAdminMiddleware(config *config.AdminConfig, logger service.Logger) *AdminMiddleware {
+	return &AdminMiddleware{
+		token:  []byte(config.Token),
+		logger: logger,
+	}
+}
+
+func (m *AdminMiddleware) Authenticate(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		token, ok := strings.CutPrefix(c.Request().Header.Get(echo.HeaderAuthorization), "Bearer ")
+		if !ok || len(m.token) == 0 || subtle.ConstantTimeCompare([]byte(token), m.token) != 1 {
+			m.logger.Warn(c.Request().Context(), "Unauthorized admin request", service.Fields{
+				"path":   c.Request().URL.Path,
+				"method": c.Request().Method,
+				"ip":     c.RealIP(),
+			})
+			return c.JSON(http.StatusUnauthorized, common.ErrorResponse{Error: http.StatusText(http.StatusUnauthorized)})
+		}
+
+		return next(c)
+	}
+}
@@ -117,7 +117,7 @@ func (m *AuthMiddleware) Authenticate(next echo.HandlerFunc) echo.HandlerFunc {
 
 func (m *AuthMiddleware) ValidateToken(tokenString string) (*TokenClaims, error) {
 	var token TokenClaims
-	if err := m.jwtService.ValidateTarget(tokenString, []byte(m.config.OAuth.ClientSecret), &token); err != nil {
+	if err := m.jwtService.ValidateTarget(tokenString, []byte(m.config.OAuth.ClientSecret), crypto.HS256, &token); err != nil {
 		return nil, err
 	}
 
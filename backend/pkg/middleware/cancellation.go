@@ -19,6 +19,7 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"time"
 
@@ -26,52 +27,74 @@ import (
 	echo "github.com/labstack/echo/v4"
 )
 
+// requestTimeout bounds requests of routes without a deadline of their own.
+const requestTimeout = 30 * time.Second
+
 type CancellationMiddleware struct {
+	exempt map[string]struct{}
 	logger service.Logger
 }
 
-func NewCancellationMiddleware(logger service.Logger) *CancellationMiddleware {
+// NewCancellationMiddleware bounds requests with a deadline, except on the
+// exempt paths whose handlers set longer deadlines of their own, such as
+// streaming a file. Requests on exempt paths are still canceled when the
+// client goes away.
+func NewCancellationMiddleware(logger service.Logger, exemptPaths ...string) *CancellationMiddleware {
+	exempt := make(map[string]struct{}, len(exemptPaths))
+	for _, path := range exemptPaths {
+		exempt[path] = struct{}{}
+	}
+
 	return &CancellationMiddleware{
+		exempt: exempt,
 		logger: logger,
 	}
 }
 
+// HandleRequestCancellation bounds every request with a deadline. The handler
+// runs on the request goroutine so that it stops together with its context
+// instead of being left running once the client has gone away.
 func (m *CancellationMiddleware) HandleRequestCancellation(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		req := c.Request()
-		ctx, cancel := context.WithTimeout(req.Context(), 30*time.Second)
+		var (
+			ctx    context.Context
+			cancel context.CancelFunc
+		)
+
+		if _, ok := m.exempt[req.URL.Path]; ok {
+			ctx, cancel = context.WithCancel(req.Context())
+		} else {
+			ctx, cancel = context.WithTimeout(req.Context(), requestTimeout)
+		}
 		defer cancel()
 
 		c.SetRequest(req.WithContext(ctx))
 
-		done := make(chan error)
-
-		go func() {
-			done <- next(c)
-		}()
-
-		select {
-		case <-ctx.Done():
-			if ctx.Err() == context.Canceled {
-				m.logger.Info(ctx, "request canceled by client",
-					service.Fields{
-						"path":   req.URL.Path,
-						"method": req.Method,
-						"error":  ctx.Err().Error(),
-					})
-				return echo.NewHTTPError(http.StatusRequestTimeout, "Request canceled")
-			} else if ctx.Err() == context.DeadlineExceeded {
-				m.logger.Info(ctx, "request timeout",
-					service.Fields{
-						"path":   req.URL.Path,
-						"method": req.Method,
-						"error":  ctx.Err().Error(),
-					})
-				return echo.NewHTTPError(http.StatusRequestTimeout, "Request timeout")
-			}
-			return echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
-		case err := <-done:
+		err := next(c)
+		if c.Response().Committed {
 			return err
 		}
+
+		switch {
+		case errors.Is(ctx.Err(), context.Canceled):
+			m.logger.Info(ctx, "request canceled by client",
+				service.Fields{
+					"path":   req.URL.Path,
+					"method": req.Method,
+					"error":  ctx.Err().Error(),
+				})
+			return echo.NewHTTPError(http.StatusRequestTimeout, "Request canceled")
+		case errors.Is(ctx.Err(), context.DeadlineExceeded):
+			m.logger.Info(ctx, "request timeout",
+				service.Fields{
+					"path":   req.URL.Path,
+					"method": req.Method,
+					"error":  ctx.Err().Error(),
+				})
+			return echo.NewHTTPError(http.StatusRequestTimeout, "Request timeout")
+		}
+
+		return err
 	}
 }
@@ -0,0 +1,70 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package middleware
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/config"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/service"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/breaker"
+)
+
+// FallbackStore counts requests in the primary store while its circuit
+// breaker is closed and in the local fallback store otherwise, so that an
+// unavailable Redis neither rejects nor stalls requests. Limits are enforced
+// per replica while degraded.
+type FallbackStore struct {
+	primary  RateLimiter
+	fallback RateLimiter
+	breaker  *breaker.Breaker
+	logger   service.Logger
+}
+
+func NewFallbackStore(primary, fallback RateLimiter, breaker *breaker.Breaker, logger service.Logger) *FallbackStore {
+	return &FallbackStore{
+		primary:  primary,
+		fallback: fallback,
+		breaker:  breaker,
+		logger:   logger,
+	}
+}
+
+func (s *FallbackStore) Take(ctx context.Context, identifier string, policy *config.RateLimitPolicy) (RateLimitResult, error) {
+	var result RateLimitResult
+	err := s.breaker.Execute(func() error {
+		var err error
+		result, err = s.primary.Take(ctx, identifier, policy)
+		return err
+	})
+
+	if err == nil {
+		return result, nil
+	}
+
+	if !errors.Is(err, breaker.ErrCircuitOpen) {
+		s.logger.Warn(ctx, "falling back to local rate limit store",
+			service.Fields{
+				"identifier": identifier,
+				"error":      err.Error(),
+			})
+	}
+
+	return s.fallback.Take(ctx, identifier, policy)
+}
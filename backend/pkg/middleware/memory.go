@@ -0,0 +1,111 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/config"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/service"
+)
+
+type bucket struct {
+	lastUpdate time.Time
+	waterLevel float64
+	window     time.Duration
+}
+
+// MemoryStore applies the same leaky bucket as RedisStore within a single
+// process. Buckets idle for more than two windows are dropped, mirroring the
+// expiration of their Redis counterparts.
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	config    *config.RateLimitConfig
+	logger    service.Logger
+}
+
+func NewMemoryStore(config *config.Config, logger service.Logger) *MemoryStore {
+	logger.Info(context.Background(), "using in-memory rate limit store", nil)
+
+	return &MemoryStore{
+		buckets:   make(map[string]*bucket),
+		lastSweep: time.Now(),
+		config:    config.RateLimit,
+		logger:    logger,
+	}
+}
+
+// sweep removes idle buckets at most once per default window. The caller
+// must hold the lock.
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < s.config.Window {
+		return
+	}
+
+	for identifier, b := range s.buckets {
+		if now.Sub(b.lastUpdate) > 2*b.window {
+			delete(s.buckets, identifier)
+		}
+	}
+
+	s.lastSweep = now
+}
+
+func (s *MemoryStore) Take(ctx context.Context, identifier string, policy *config.RateLimitPolicy) (RateLimitResult, error) {
+	if identifier == "" {
+		return RateLimitResult{}, fmt.Errorf("identifier cannot be empty")
+	}
+
+	now := time.Now()
+	capacity := float64(policy.Rate)
+	leakRate := float64(policy.Rate) / float64(policy.Window.Milliseconds())
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sweep(now)
+
+	b, ok := s.buckets[identifier]
+	if !ok {
+		b = &bucket{lastUpdate: now}
+		s.buckets[identifier] = b
+	}
+
+	leaked := float64(now.Sub(b.lastUpdate).Milliseconds()) * leakRate
+	b.waterLevel = max(0, b.waterLevel-leaked)
+	b.lastUpdate = now
+	b.window = policy.Window
+
+	if b.waterLevel+1 > capacity {
+		s.logger.Warn(ctx, "rate limit exceeded",
+			service.Fields{
+				"identifier": identifier,
+				"rate":       policy.Rate,
+				"window":     policy.Window.String(),
+			})
+		return newRateLimitResult(false, b.waterLevel, policy), nil
+	}
+
+	b.waterLevel++
+	return newRateLimitResult(true, b.waterLevel, policy), nil
+}
@@ -0,0 +1,73 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/metrics"
+	echo "github.com/labstack/echo/v4"
+)
+
+// unmatchedRoute labels requests that matched no route, so that scanners
+// probing arbitrary paths cannot inflate the number of series.
+const unmatchedRoute = "unmatched"
+
+type MetricsMiddleware struct {
+	metrics *metrics.Metrics
+}
+
+func NewMetricsMiddleware(metrics *metrics.Metrics) *MetricsMiddleware {
+	return &MetricsMiddleware{
+		metrics: metrics,
+	}
+}
+
+// Observe records the latency and status of every request, labelled by the
+// route template rather than the request path.
+func (m *MetricsMiddleware) Observe(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		start := time.Now()
+		err := next(c)
+
+		route := c.Path()
+		if route == "" {
+			route = unmatchedRoute
+		}
+
+		m.metrics.ObserveHTTPRequest(c.Request().Method, route, responseStatus(c, err), time.Since(start))
+		return err
+	}
+}
+
+// responseStatus predicts the status the error handler will send when the
+// handler returned an error without writing a response.
+func responseStatus(c echo.Context, err error) int {
+	if err == nil || c.Response().Committed {
+		return c.Response().Status
+	}
+
+	var herr *echo.HTTPError
+	if errors.As(err, &herr) {
+		return herr.Code
+	}
+
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,154 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package middleware
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/config"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/service"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/common"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/middleware/authentication"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/metrics"
+	echo "github.com/labstack/echo/v4"
+	echomiddleware "github.com/labstack/echo/v4/middleware"
+)
+
+const (
+	headerRateLimitLimit     = "X-RateLimit-Limit"
+	headerRateLimitRemaining = "X-RateLimit-Remaining"
+	headerRateLimitReset     = "X-RateLimit-Reset"
+)
+
+// RateLimiter counts a request against the bucket of identifier.
+type RateLimiter interface {
+	Take(ctx context.Context, identifier string, policy *config.RateLimitPolicy) (RateLimitResult, error)
+}
+
+// RateLimitResult describes a bucket after a request has been counted.
+type RateLimitResult struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	RetryAfter time.Duration
+	Reset      time.Duration
+}
+
+// newRateLimitResult derives the response metadata from the water level of a
+// leaky bucket draining policy.Rate requests per policy.Window.
+func newRateLimitResult(allowed bool, waterLevel float64, policy *config.RateLimitPolicy) RateLimitResult {
+	leakRate := float64(policy.Rate) / float64(policy.Window)
+	result := RateLimitResult{
+		Allowed:   allowed,
+		Limit:     policy.Rate,
+		Remaining: max(0, int(math.Floor(float64(policy.Rate)-waterLevel))),
+		Reset:     time.Duration(waterLevel / leakRate),
+	}
+
+	if !allowed {
+		result.RetryAfter = time.Duration((waterLevel + 1 - float64(policy.Rate)) / leakRate)
+	}
+
+	return result
+}
+
+// RateLimitMiddleware applies the named policies of the rate limit
+// configuration. Authenticated requests are counted per user or team as the
+// policy requires, anything else per client address.
+type RateLimitMiddleware struct {
+	store   RateLimiter
+	config  *config.RateLimitConfig
+	skip    map[string]struct{}
+	metrics *metrics.Metrics
+	logger  service.Logger
+}
+
+func NewRateLimitMiddleware(
+	store RateLimiter,
+	config *config.RateLimitConfig,
+	metrics *metrics.Metrics,
+	logger service.Logger,
+) *RateLimitMiddleware {
+	skip := make(map[string]struct{}, len(config.SkipPaths))
+	for _, path := range config.SkipPaths {
+		skip[path] = struct{}{}
+	}
+
+	return &RateLimitMiddleware{
+		store:   store,
+		config:  config,
+		skip:    skip,
+		metrics: metrics,
+		logger:  logger,
+	}
+}
+
+func (m *RateLimitMiddleware) identifier(c echo.Context, name string, policy *config.RateLimitPolicy) string {
+	if claims, ok := c.Get(common.ContextKeyUser).(*authentication.TokenClaims); ok && claims.Team != "" {
+		switch policy.Key {
+		case config.RateLimitKeyUser:
+			if claims.User != "" {
+				return name + ":user:" + claims.Team + ":" + claims.User
+			}
+		case config.RateLimitKeyTeam:
+			return name + ":team:" + claims.Team
+		}
+	}
+
+	return name + ":ip:" + c.RealIP()
+}
+
+// Limit returns a middleware enforcing the named policy. It must run after
+// authentication for user and team keyed policies to take effect.
+func (m *RateLimitMiddleware) Limit(name string) echo.MiddlewareFunc {
+	policy := m.config.Policy(name)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if _, ok := m.skip[c.Request().URL.Path]; ok {
+				return next(c)
+			}
+
+			identifier := m.identifier(c, name, policy)
+			result, err := m.store.Take(c.Request().Context(), identifier, policy)
+			if err != nil {
+				return &echo.HTTPError{
+					Code:     echomiddleware.ErrRateLimitExceeded.Code,
+					Message:  echomiddleware.ErrRateLimitExceeded.Message,
+					Internal: err,
+				}
+			}
+
+			header := c.Response().Header()
+			header.Set(headerRateLimitLimit, strconv.Itoa(result.Limit))
+			header.Set(headerRateLimitRemaining, strconv.Itoa(result.Remaining))
+			header.Set(headerRateLimitReset, strconv.Itoa(int(math.Ceil(result.Reset.Seconds()))))
+
+			if !result.Allowed {
+				m.metrics.ObserveRateLimitDenial(name)
+				header.Set(echo.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(result.RetryAfter.Seconds()))))
+				return echomiddleware.ErrRateLimitExceeded
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -20,6 +20,7 @@ package middleware
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/config"
@@ -27,13 +28,8 @@ import (
 	redis "github.com/redis/go-redis/v9"
 )
 
-type RateLimiter interface {
-	Allow(identifier string) (bool, error)
-}
-
 type RedisStore struct {
-	client      *redis.Client
-	config      *config.RateLimitConfig
+	client      redis.UniversalClient
 	logger      service.Logger
 	leakyScript *redis.Script
 }
@@ -56,72 +52,43 @@ water_level = math.max(0, water_level - leaked)
 
 if water_level + 1 > capacity then
     redis.call('HMSET', key, 'last_update', now, 'water_level', water_level)
-    redis.call('EXPIRE', key, window / 1000 * 2)
-    return 0
+    redis.call('EXPIRE', key, math.ceil(window / 1000 * 2))
+    return {0, tostring(water_level)}
 end
 
 water_level = water_level + 1
 redis.call('HMSET', key, 'last_update', now, 'water_level', water_level)
-redis.call('EXPIRE', key, window / 1000 * 2)
-return 1
+redis.call('EXPIRE', key, math.ceil(window / 1000 * 2))
+return {1, tostring(water_level)}
 `
 
-func NewRedisStore(config *config.Config, logger service.Logger) (*RedisStore, error) {
-	client := redis.NewClient(&redis.Options{
-		Addr:     fmt.Sprintf("%s:%d", config.Redis.Host, config.Redis.Port),
-		Password: config.Redis.Password,
-		DB:       config.Redis.DB,
-	})
-
-	ctx, cancel := context.WithTimeout(context.Background(), config.Redis.Timeout)
-	defer cancel()
-
-	logger.Info(ctx, "connecting to Redis",
-		service.Fields{
-			"host":     config.Redis.Host,
-			"port":     config.Redis.Port,
-			"database": config.Redis.DB,
-		})
-
-	if err := client.Ping(ctx).Err(); err != nil {
-		logger.Error(ctx, "failed to connect to Redis",
-			service.Fields{
-				"error": err.Error(),
-			})
-		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
-	}
-
-	logger.Info(ctx, "successfully connected to Redis")
-
+func NewRedisStore(client redis.UniversalClient, logger service.Logger) *RedisStore {
 	return &RedisStore{
 		client:      client,
-		config:      config.RateLimit,
 		logger:      logger,
 		leakyScript: redis.NewScript(leakyBucketScript),
-	}, nil
+	}
 }
 
-func (s *RedisStore) Allow(identifier string) (bool, error) {
+func (s *RedisStore) Take(ctx context.Context, identifier string, policy *config.RateLimitPolicy) (RateLimitResult, error) {
 	if identifier == "" {
-		return false, fmt.Errorf("identifier cannot be empty")
+		return RateLimitResult{}, fmt.Errorf("identifier cannot be empty")
 	}
 
-	tctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	tctx, cancel := context.WithTimeout(ctx, 1*time.Second)
 	defer cancel()
 
 	key := fmt.Sprintf("rate_limit:%s", identifier)
-	wms := int64(s.config.Window.Milliseconds())
+	wms := policy.Window.Milliseconds()
 	nms := time.Now().UnixMilli()
 
-	capacity := s.config.Rate
-
-	allowed, err := s.leakyScript.Run(tctx, s.client,
+	reply, err := s.leakyScript.Run(tctx, s.client,
 		[]string{key},
-		s.config.Rate,
-		capacity,
+		policy.Rate,
+		policy.Rate,
 		nms,
 		wms,
-	).Int()
+	).Slice()
 
 	if err != nil {
 		s.logger.Error(tctx, "error processing rate limit",
@@ -129,18 +96,28 @@ func (s *RedisStore) Allow(identifier string) (bool, error) {
 				"identifier": identifier,
 				"error":      err.Error(),
 			})
-		return false, err
+		return RateLimitResult{}, err
+	}
+
+	if len(reply) != 2 {
+		return RateLimitResult{}, fmt.Errorf("unexpected rate limit reply: %v", reply)
+	}
+
+	allowed, _ := reply[0].(int64)
+	level, _ := reply[1].(string)
+	waterLevel, err := strconv.ParseFloat(level, 64)
+	if err != nil {
+		return RateLimitResult{}, fmt.Errorf("invalid rate limit water level: %w", err)
 	}
 
 	if allowed == 0 {
 		s.logger.Warn(tctx, "rate limit exceeded",
 			service.Fields{
 				"identifier": identifier,
-				"rate":       s.config.Rate,
-				"window":     s.config.Window.String(),
+				"rate":       policy.Rate,
+				"window":     policy.Window.String(),
 			})
-		return false, nil
 	}
 
-	return true, nil
+	return newRateLimitResult(allowed == 1, waterLevel, policy), nil
 }
@@ -0,0 +1,214 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package breaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/service"
+)
+
+type State int
+
+const (
+	StateClosed State = iota
+	StateOpen
+	StateHalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// Breaker is a consecutive failure circuit breaker. Once the threshold is
+// reached calls fail fast with ErrCircuitOpen until the recovery timeout has
+// elapsed, after which a single probe decides whether the circuit closes.
+type Breaker struct {
+	name    string
+	options *BreakerOptions
+	logger  service.Logger
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+func NewBreaker(name string, logger service.Logger, opts ...Option) (*Breaker, error) {
+	options := DefaultBreakerOptions()
+
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	if err := options.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid breaker options: %w", err)
+	}
+
+	return &Breaker{
+		name:    name,
+		options: options,
+		logger:  logger,
+	}, nil
+}
+
+// Name returns the name the breaker was created with.
+func (b *Breaker) Name() string {
+	return b.name
+}
+
+// State returns the current state, reporting an open circuit whose recovery
+// timeout has elapsed as half-open.
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == StateOpen && time.Since(b.openedAt) >= b.options.RecoveryTimeout {
+		return StateHalfOpen
+	}
+
+	return b.state
+}
+
+// Allow reports whether a call may proceed. Every allowed call must be
+// followed by Success or Failure.
+func (b *Breaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case StateOpen:
+		if time.Since(b.openedAt) < b.options.RecoveryTimeout {
+			return ErrCircuitOpen
+		}
+		b.transition(StateHalfOpen)
+		b.probing = true
+		return nil
+	case StateHalfOpen:
+		if b.probing {
+			return ErrCircuitOpen
+		}
+		b.probing = true
+		return nil
+	default:
+		return nil
+	}
+}
+
+// Success records a successful call and closes a half-open circuit.
+func (b *Breaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = 0
+	b.probing = false
+	if b.state != StateClosed {
+		b.transition(StateClosed)
+	}
+}
+
+// Failure records a failed call, opening the circuit once the threshold is
+// reached or immediately when the failed call was a probe.
+func (b *Breaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	b.probing = false
+	if b.state == StateHalfOpen || b.failures >= b.options.FailureThreshold {
+		b.open()
+	}
+}
+
+// Trip opens the circuit regardless of the failure count.
+func (b *Breaker) Trip() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.open()
+}
+
+// Execute runs fn if the circuit allows it and records its outcome. Calls
+// cancelled by their caller say nothing about the upstream and are not counted.
+func (b *Breaker) Execute(fn func() error) error {
+	if err := b.Allow(); err != nil {
+		return err
+	}
+
+	err := fn()
+	switch {
+	case err == nil:
+		b.Success()
+	case errors.Is(err, context.Canceled):
+		b.release()
+	default:
+		b.Failure()
+	}
+
+	return err
+}
+
+// release gives up a probe without recording an outcome.
+func (b *Breaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
+
+// open moves the circuit to the open state. The caller must hold the lock.
+func (b *Breaker) open() {
+	b.openedAt = time.Now()
+	if b.state != StateOpen {
+		b.transition(StateOpen)
+	}
+}
+
+// transition changes the state and logs it. The caller must hold the lock.
+func (b *Breaker) transition(state State) {
+	b.logger.Warn(context.Background(), "Circuit breaker state changed",
+		service.Fields{
+			"breaker":  b.name,
+			"from":     b.state.String(),
+			"to":       state.String(),
+			"failures": b.failures,
+		})
+	b.state = state
+}
+
+// Status reports the breaker for observability.
+func (b *Breaker) Status() Status {
+	return Status{
+		Name:  b.name,
+		State: b.State().String(),
+	}
+}
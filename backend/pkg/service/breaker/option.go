@@ -0,0 +1,63 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package breaker
+
+import (
+	"fmt"
+	"time"
+)
+
+type BreakerOptions struct {
+	// FailureThreshold is the number of consecutive failures opening the circuit.
+	FailureThreshold int
+	// RecoveryTimeout is how long the circuit stays open before a probe is let through.
+	RecoveryTimeout time.Duration
+}
+
+func DefaultBreakerOptions() *BreakerOptions {
+	return &BreakerOptions{
+		FailureThreshold: 5,
+		RecoveryTimeout:  30 * time.Second,
+	}
+}
+
+func (o *BreakerOptions) Validate() error {
+	if o.FailureThreshold <= 0 {
+		return fmt.Errorf("failure threshold must be positive")
+	}
+
+	if o.RecoveryTimeout <= 0 {
+		return fmt.Errorf("recovery timeout must be positive")
+	}
+
+	return nil
+}
+
+type Option func(*BreakerOptions)
+
+func WithFailureThreshold(threshold int) Option {
+	return func(o *BreakerOptions) {
+		o.FailureThreshold = threshold
+	}
+}
+
+func WithRecoveryTimeout(timeout time.Duration) Option {
+	return func(o *BreakerOptions) {
+		o.RecoveryTimeout = timeout
+	}
+}
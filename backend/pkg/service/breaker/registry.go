@@ -0,0 +1,135 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package breaker
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/service"
+)
+
+// maxGuards bounds the number of hosts tracked by a registry. Document
+// Servers are configured per board, so the set of hosts is open ended.
+const maxGuards = 1024
+
+// Status describes a circuit breaker for observability.
+type Status struct {
+	Name     string `json:"name"`
+	Host     string `json:"host,omitempty"`
+	State    string `json:"state"`
+	InFlight int    `json:"in_flight"`
+}
+
+// Guard pairs the circuit breaker and bulkhead protecting a single host.
+type Guard struct {
+	Breaker  *Breaker
+	Bulkhead *Bulkhead
+}
+
+// Registry lazily creates one guard per upstream host.
+type Registry struct {
+	name          string
+	maxConcurrent int
+	maxWait       time.Duration
+	options       []Option
+	logger        service.Logger
+
+	mu     sync.Mutex
+	guards map[string]*Guard
+}
+
+func NewRegistry(
+	name string,
+	maxConcurrent int,
+	maxWait time.Duration,
+	logger service.Logger,
+	opts ...Option,
+) (*Registry, error) {
+	// Build a breaker up front so that invalid options fail at startup rather
+	// than on the first request.
+	if _, err := NewBreaker(name, logger, opts...); err != nil {
+		return nil, err
+	}
+
+	return &Registry{
+		name:          name,
+		maxConcurrent: maxConcurrent,
+		maxWait:       maxWait,
+		options:       opts,
+		logger:        logger,
+		guards:        make(map[string]*Guard),
+	}, nil
+}
+
+// Name returns the name of the upstream service the registry guards.
+func (r *Registry) Name() string {
+	return r.name
+}
+
+// For returns the guard of host, creating it on first use. Once the limit is
+// reached an idle guard with a closed circuit is evicted.
+func (r *Registry) For(host string) *Guard {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if guard, ok := r.guards[host]; ok {
+		return guard
+	}
+
+	if len(r.guards) >= maxGuards {
+		for h, guard := range r.guards {
+			if guard.Breaker.State() == StateClosed && guard.Bulkhead.InFlight() == 0 {
+				delete(r.guards, h)
+				break
+			}
+		}
+	}
+
+	// Options were validated by NewRegistry.
+	b, _ := NewBreaker(r.name+" "+host, r.logger, r.options...)
+	guard := &Guard{
+		Breaker:  b,
+		Bulkhead: NewBulkhead(r.maxConcurrent, r.maxWait),
+	}
+
+	r.guards[host] = guard
+	return guard
+}
+
+// Statuses reports the state of every tracked host, sorted by host.
+func (r *Registry) Statuses() []Status {
+	r.mu.Lock()
+	statuses := make([]Status, 0, len(r.guards))
+	for host, guard := range r.guards {
+		statuses = append(statuses, Status{
+			Name:     r.name,
+			Host:     host,
+			State:    guard.Breaker.State().String(),
+			InFlight: guard.Bulkhead.InFlight(),
+		})
+	}
+	r.mu.Unlock()
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Host < statuses[j].Host
+	})
+
+	return statuses
+}
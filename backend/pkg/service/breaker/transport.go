@@ -0,0 +1,106 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package breaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+type guardedTransport struct {
+	transport http.RoundTripper
+	registry  *Registry
+}
+
+// NewTransport guards rt with the circuit breaker and bulkhead of each
+// request's host. Requests fail fast while a host's circuit is open or all of
+// its slots are taken. Transport errors and 5xx responses count as failures.
+// A slot is held until the response body is closed, so callers must close it.
+func NewTransport(rt http.RoundTripper, registry *Registry) http.RoundTripper {
+	if registry == nil {
+		return rt
+	}
+
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+
+	return &guardedTransport{
+		transport: rt,
+		registry:  registry,
+	}
+}
+
+func (t *guardedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	guard := t.registry.For(req.URL.Host)
+
+	if err := guard.Bulkhead.Acquire(req.Context()); err != nil {
+		return nil, fmt.Errorf("%s %s: %w", t.registry.Name(), req.URL.Host, err)
+	}
+
+	var resp *http.Response
+	var rerr error
+	err := guard.Breaker.Execute(func() error {
+		resp, rerr = t.transport.RoundTrip(req)
+		if rerr != nil {
+			// A cancelled caller says nothing about the upstream, while a
+			// deadline exceeded inside the transport means it was too slow.
+			if errors.Is(req.Context().Err(), context.Canceled) {
+				return context.Canceled
+			}
+			return rerr
+		}
+
+		if resp.StatusCode >= http.StatusInternalServerError {
+			return fmt.Errorf("upstream responded with status %d", resp.StatusCode)
+		}
+
+		return nil
+	})
+
+	if resp != nil && resp.Body != nil {
+		// The slot stays taken while the body is streamed and is freed once
+		// the caller closes it.
+		resp.Body = &releasingBody{ReadCloser: resp.Body, release: guard.Bulkhead.Release}
+		return resp, rerr
+	}
+
+	guard.Bulkhead.Release()
+	if resp != nil || rerr != nil {
+		return resp, rerr
+	}
+
+	return nil, fmt.Errorf("%s %s: %w", t.registry.Name(), req.URL.Host, err)
+}
+
+// releasingBody frees a bulkhead slot when the response body is closed.
+type releasingBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
@@ -0,0 +1,247 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/service"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/breaker"
+	redis "github.com/redis/go-redis/v9"
+)
+
+const (
+	invalidationChannel   = "invalidations"
+	invalidationSeparator = "|"
+	// subscribeTimeout bounds a single attempt to subscribe to invalidations,
+	// and subscribeRetryInterval spaces the attempts while Redis is down.
+	subscribeTimeout       = 5 * time.Second
+	subscribeRetryInterval = 5 * time.Second
+)
+
+// ChainedCache serves reads from an in-process cache in front of Redis.
+// Writes and deletions go to both levels and are broadcast over Redis
+// pub/sub so that other replicas evict their local copies. Messages missed
+// while the subscription reconnects are covered by the short local
+// expiration.
+type ChainedCache struct {
+	local           *MemoryCache
+	remote          *RedisCache
+	localExpiration time.Duration
+	instance        string
+	pubsub          *redis.PubSub
+	done            chan struct{}
+	logger          service.Logger
+
+	localHits     atomic.Uint64
+	remoteHits    atomic.Uint64
+	misses        atomic.Uint64
+	invalidations atomic.Uint64
+}
+
+func NewChainedCache(
+	local *MemoryCache,
+	remote *RedisCache,
+	localExpiration time.Duration,
+	logger service.Logger,
+) (*ChainedCache, error) {
+	if localExpiration <= 0 {
+		return nil, fmt.Errorf("local expiration must be positive")
+	}
+
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, fmt.Errorf("failed to generate cache instance id: %w", err)
+	}
+
+	cache := &ChainedCache{
+		local:           local,
+		remote:          remote,
+		localExpiration: localExpiration,
+		instance:        hex.EncodeToString(buf),
+		pubsub:          remote.pubSub(),
+		done:            make(chan struct{}),
+		logger:          logger,
+	}
+
+	go cache.listen()
+
+	logger.Info(context.Background(), "Chained cache initialized",
+		service.Fields{
+			"instance":         cache.instance,
+			"local_expiration": localExpiration.String(),
+		})
+
+	return cache, nil
+}
+
+// listen evicts local entries invalidated by other replicas until the cache
+// is closed. Subscribing happens here rather than in the constructor so that
+// an unavailable Redis does not prevent startup; messages missed meanwhile
+// are covered by the short local expiration.
+func (c *ChainedCache) listen() {
+	if !c.subscribe() {
+		return
+	}
+
+	for message := range c.pubsub.Channel() {
+		instance, key, ok := strings.Cut(message.Payload, invalidationSeparator)
+		if !ok || instance == c.instance {
+			continue
+		}
+
+		ctx := context.Background()
+		if err := c.local.Delete(ctx, key); err == nil {
+			c.invalidations.Add(1)
+			c.logger.Debug(ctx, "Local cache entry invalidated by another replica",
+				service.Fields{
+					"key":      key,
+					"instance": instance,
+				})
+		}
+	}
+}
+
+// subscribe retries until the invalidation subscription succeeds, reporting
+// false if the cache is closed first. Once subscribed, the Redis client
+// reconnects the subscription on its own.
+func (c *ChainedCache) subscribe() bool {
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), subscribeTimeout)
+		err := c.remote.subscribe(ctx, c.pubsub, invalidationChannel)
+		cancel()
+
+		if err == nil {
+			c.logger.Info(context.Background(), "Subscribed to cache invalidations", nil)
+			return true
+		}
+
+		if !errors.Is(err, breaker.ErrCircuitOpen) {
+			c.logger.Warn(context.Background(), "Failed to subscribe to cache invalidations, retrying",
+				service.Fields{
+					"error": err.Error(),
+				})
+		}
+
+		select {
+		case <-c.done:
+			return false
+		case <-time.After(subscribeRetryInterval):
+		}
+	}
+}
+
+// broadcast asks other replicas to evict key from their local caches. It is
+// skipped while the Redis circuit is open, as no replica could receive it.
+func (c *ChainedCache) broadcast(ctx context.Context, key string) {
+	err := c.remote.publish(ctx, invalidationChannel, c.instance+invalidationSeparator+key)
+	if errors.Is(err, breaker.ErrCircuitOpen) {
+		c.logger.Debug(ctx, "Cache unavailable, skipping invalidation broadcast",
+			service.Fields{
+				"key": key,
+			})
+		return
+	}
+
+	if err != nil {
+		c.logger.Warn(ctx, "Failed to broadcast cache invalidation",
+			service.Fields{
+				"key":   key,
+				"error": err.Error(),
+			})
+	}
+}
+
+func (c *ChainedCache) Get(ctx context.Context, key string) ([]byte, error) {
+	if value, _ := c.local.Get(ctx, key); value != nil {
+		c.localHits.Add(1)
+		return value, nil
+	}
+
+	value, err := c.remote.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if value == nil {
+		c.misses.Add(1)
+		return nil, nil
+	}
+
+	c.remoteHits.Add(1)
+	_ = c.local.Set(ctx, key, value, c.localExpiration)
+
+	return value, nil
+}
+
+func (c *ChainedCache) Set(ctx context.Context, key string, value []byte, expiration time.Duration) error {
+	if err := c.remote.Set(ctx, key, value, expiration); err != nil {
+		_ = c.local.Delete(ctx, key)
+		return err
+	}
+
+	localExpiration := c.localExpiration
+	if expiration > 0 && expiration < localExpiration {
+		localExpiration = expiration
+	}
+
+	_ = c.local.Set(ctx, key, value, localExpiration)
+	c.broadcast(ctx, key)
+
+	return nil
+}
+
+func (c *ChainedCache) Delete(ctx context.Context, key string) error {
+	_ = c.local.Delete(ctx, key)
+	err := c.remote.Delete(ctx, key)
+	c.broadcast(ctx, key)
+
+	return err
+}
+
+// GetDel takes the value from Redis, which is the only level shared by all
+// replicas, and evicts every local copy.
+func (c *ChainedCache) GetDel(ctx context.Context, key string) ([]byte, error) {
+	_ = c.local.Delete(ctx, key)
+	value, err := c.remote.GetDel(ctx, key)
+	c.broadcast(ctx, key)
+
+	return value, err
+}
+
+func (c *ChainedCache) Stats() Stats {
+	return Stats{
+		LocalHits:     c.localHits.Load(),
+		RemoteHits:    c.remoteHits.Load(),
+		Misses:        c.misses.Load(),
+		Invalidations: c.invalidations.Load(),
+	}
+}
+
+// Close stops listening for invalidations.
+func (c *ChainedCache) Close() error {
+	close(c.done)
+	return c.pubsub.Close()
+}
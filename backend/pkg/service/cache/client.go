@@ -0,0 +1,135 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package cache
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/config"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/service"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/breaker"
+	redis "github.com/redis/go-redis/v9"
+)
+
+// NewRedisClient connects to Redis using the topology described by cfg and
+// returns a client shared by the cache, the locker and the rate limiter.
+// When guarded by a circuit breaker an unreachable server does not prevent
+// startup: the breaker is tripped and the client reconnects once Redis is back.
+func NewRedisClient(cfg *config.RedisConfig, cb *breaker.Breaker, logger service.Logger) (redis.UniversalClient, error) {
+	tlsConfig, err := newTLSConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Redis TLS configuration: %w", err)
+	}
+
+	addresses := cfg.Addresses
+	if cfg.Mode == config.RedisModeStandalone {
+		addresses = []string{cfg.Address()}
+	}
+
+	options := &redis.UniversalOptions{
+		Addrs:            addresses,
+		MasterName:       cfg.MasterName,
+		Username:         cfg.Username,
+		Password:         cfg.Password,
+		SentinelUsername: cfg.SentinelUsername,
+		SentinelPassword: cfg.SentinelPassword,
+		DB:               cfg.DB,
+		TLSConfig:        tlsConfig,
+		PoolSize:         20,
+		MinIdleConns:     2,
+		DialTimeout:      2 * time.Second,
+		ReadTimeout:      2 * time.Second,
+		WriteTimeout:     3 * time.Second,
+	}
+
+	var client redis.UniversalClient
+	switch cfg.Mode {
+	case config.RedisModeSentinel:
+		client = redis.NewFailoverClient(options.Failover())
+	case config.RedisModeCluster:
+		client = redis.NewClusterClient(options.Cluster())
+	default:
+		client = redis.NewClient(options.Simple())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	defer cancel()
+
+	logger.Info(ctx, "Connecting to Redis",
+		service.Fields{
+			"mode":      cfg.Mode,
+			"addresses": addresses,
+			"db":        cfg.DB,
+			"tls":       cfg.TLS,
+		})
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		if cb != nil {
+			logger.Warn(ctx, "Redis is unavailable, starting in degraded mode",
+				service.Fields{
+					"error": err.Error(),
+				})
+			cb.Trip()
+			return client, nil
+		}
+
+		logger.Error(ctx, "Failed to connect to Redis",
+			service.Fields{
+				"error": err.Error(),
+			})
+		client.Close()
+		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+
+	logger.Info(ctx, "Successfully connected to Redis")
+
+	return client, nil
+}
+
+func newTLSConfig(cfg *config.RedisConfig) (*tls.Config, error) {
+	if !cfg.TLS {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: cfg.TLSInsecureSkipVerify,
+	}
+
+	if cfg.TLSCAFile == "" {
+		return tlsConfig, nil
+	}
+
+	ca, err := os.ReadFile(cfg.TLSCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA file: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, fmt.Errorf("no certificates found in %s", cfg.TLSCAFile)
+	}
+
+	tlsConfig.RootCAs = pool
+	return tlsConfig, nil
+}
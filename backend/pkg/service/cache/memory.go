@@ -0,0 +1,202 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package cache
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/service"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/metrics"
+)
+
+type memoryEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// MemoryCache is an in-process LRU cache with per-entry expiration. It is
+// meant for single-instance deployments, as entries are not shared between
+// replicas.
+type MemoryCache struct {
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+	options *CacheOptions
+	logger  service.Logger
+}
+
+func NewMemoryCache(logger service.Logger, opts ...Option) (*MemoryCache, error) {
+	options := DefaultCacheOptions()
+
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	if err := options.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid cache options: %w", err)
+	}
+
+	logger.Info(context.Background(), "Using in-memory cache",
+		service.Fields{
+			"capacity":   options.Capacity,
+			"key_prefix": options.KeyPrefix,
+		})
+
+	return &MemoryCache{
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+		options: options,
+		logger:  logger,
+	}, nil
+}
+
+func (c *MemoryCache) buildKey(key string) string {
+	return c.options.KeyPrefix + key
+}
+
+// removeElement drops an entry. The caller must hold the lock.
+func (c *MemoryCache) removeElement(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*memoryEntry).key)
+}
+
+func (c *MemoryCache) Get(ctx context.Context, key string) ([]byte, error) {
+	cacheKey := c.buildKey(key)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[cacheKey]
+	if !ok {
+		c.options.Metrics.ObserveCacheLookup(metrics.CacheTierMemory, false)
+		c.logger.Debug(ctx, "Cache miss",
+			service.Fields{
+				"key": cacheKey,
+			})
+		return nil, nil
+	}
+
+	entry := element.Value.(*memoryEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.removeElement(element)
+		c.options.Metrics.ObserveCacheLookup(metrics.CacheTierMemory, false)
+		c.logger.Debug(ctx, "Cache miss",
+			service.Fields{
+				"key": cacheKey,
+			})
+		return nil, nil
+	}
+
+	c.order.MoveToFront(element)
+	c.options.Metrics.ObserveCacheLookup(metrics.CacheTierMemory, true)
+	c.logger.Debug(ctx, "Cache hit",
+		service.Fields{
+			"key":  cacheKey,
+			"size": len(entry.value),
+		})
+
+	return append([]byte(nil), entry.value...), nil
+}
+
+func (c *MemoryCache) Set(ctx context.Context, key string, value []byte, expiration time.Duration) error {
+	cacheKey := c.buildKey(key)
+
+	if expiration == 0 {
+		expiration = c.options.DefaultExpiration
+	}
+
+	entry := &memoryEntry{
+		key:       cacheKey,
+		value:     append([]byte(nil), value...),
+		expiresAt: time.Now().Add(expiration),
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[cacheKey]; ok {
+		element.Value = entry
+		c.order.MoveToFront(element)
+	} else {
+		c.entries[cacheKey] = c.order.PushFront(entry)
+	}
+
+	for c.order.Len() > c.options.Capacity {
+		c.removeElement(c.order.Back())
+	}
+
+	c.logger.Debug(ctx, "Value stored in cache",
+		service.Fields{
+			"key":        cacheKey,
+			"size":       len(value),
+			"expiration": expiration.String(),
+		})
+
+	return nil
+}
+
+func (c *MemoryCache) Delete(ctx context.Context, key string) error {
+	cacheKey := c.buildKey(key)
+
+	c.mu.Lock()
+	if element, ok := c.entries[cacheKey]; ok {
+		c.removeElement(element)
+	}
+	c.mu.Unlock()
+
+	c.logger.Debug(ctx, "Key deleted from cache",
+		service.Fields{
+			"key": cacheKey,
+		})
+
+	return nil
+}
+
+func (c *MemoryCache) GetDel(ctx context.Context, key string) ([]byte, error) {
+	cacheKey := c.buildKey(key)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[cacheKey]
+	if !ok {
+		c.options.Metrics.ObserveCacheLookup(metrics.CacheTierMemory, false)
+		return nil, nil
+	}
+
+	entry := element.Value.(*memoryEntry)
+	c.removeElement(element)
+	if time.Now().After(entry.expiresAt) {
+		c.options.Metrics.ObserveCacheLookup(metrics.CacheTierMemory, false)
+		return nil, nil
+	}
+
+	c.options.Metrics.ObserveCacheLookup(metrics.CacheTierMemory, true)
+	c.logger.Debug(ctx, "Key taken from cache",
+		service.Fields{
+			"key":  cacheKey,
+			"size": len(entry.value),
+		})
+
+	return entry.value, nil
+}
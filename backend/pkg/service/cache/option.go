@@ -18,22 +18,38 @@
 package cache
 
 import (
+	"fmt"
 	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/breaker"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/metrics"
 )
 
 type CacheOptions struct {
 	KeyPrefix         string
 	DefaultExpiration time.Duration
+	// Capacity bounds the number of entries kept by in-memory caches.
+	Capacity int
+	// Breaker guards remote caches. While it is open lookups miss, and
+	// stores and deletions fail without reaching the server.
+	Breaker *breaker.Breaker
+	// Metrics counts lookup hits and misses, labelled by the cache tier.
+	Metrics *metrics.Metrics
 }
 
 func DefaultCacheOptions() *CacheOptions {
 	return &CacheOptions{
 		KeyPrefix:         "app:cache:",
 		DefaultExpiration: 5 * time.Minute,
+		Capacity:          10000,
 	}
 }
 
 func (o *CacheOptions) Validate() error {
+	if o.Capacity <= 0 {
+		return fmt.Errorf("capacity must be positive")
+	}
+
 	return nil
 }
 
@@ -51,6 +67,24 @@ func WithDefaultExpiration(duration time.Duration) Option {
 	}
 }
 
+func WithCapacity(capacity int) Option {
+	return func(o *CacheOptions) {
+		o.Capacity = capacity
+	}
+}
+
+func WithBreaker(b *breaker.Breaker) Option {
+	return func(o *CacheOptions) {
+		o.Breaker = b
+	}
+}
+
+func WithMetrics(m *metrics.Metrics) Option {
+	return func(o *CacheOptions) {
+		o.Metrics = m
+	}
+}
+
 func ApplyOptions(o *CacheOptions, opts ...Option) {
 	for _, opt := range opts {
 		opt(o)
@@ -19,22 +19,29 @@ package cache
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
-	"github.com/ONLYOFFICE/onlyoffice-miro/backend/config"
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/service"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/breaker"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/metrics"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/tracing"
 	redis "github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
+var tracer = otel.Tracer("github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/cache")
+
 type RedisCache struct {
-	client  *redis.Client
+	client  redis.UniversalClient
 	options *CacheOptions
 	logger  service.Logger
 }
 
-// TODO: Chained cache
-func NewRedisCache(cfg *config.RedisConfig, logger service.Logger, opts ...Option) (*RedisCache, error) {
+func NewRedisCache(client redis.UniversalClient, logger service.Logger, opts ...Option) (*RedisCache, error) {
 	options := DefaultCacheOptions()
 
 	for _, opt := range opts {
@@ -45,38 +52,11 @@ func NewRedisCache(cfg *config.RedisConfig, logger service.Logger, opts ...Optio
 		return nil, fmt.Errorf("invalid cache options: %w", err)
 	}
 
-	client := redis.NewClient(&redis.Options{
-		Addr:         fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
-		Password:     cfg.Password,
-		DB:           cfg.DB,
-		PoolSize:     10,
-		MinIdleConns: 2,
-		DialTimeout:  2 * time.Second,
-		ReadTimeout:  2 * time.Second,
-		WriteTimeout: 3 * time.Second,
-	})
-
-	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
-	defer cancel()
-
-	logger.Info(ctx, "Connecting to Redis cache",
+	logger.Info(context.Background(), "Redis cache initialized",
 		service.Fields{
-			"host":       cfg.Host,
-			"port":       cfg.Port,
-			"db":         cfg.DB,
 			"key_prefix": options.KeyPrefix,
 		})
 
-	if err := client.Ping(ctx).Err(); err != nil {
-		logger.Error(ctx, "Failed to connect to Redis cache",
-			service.Fields{
-				"error": err.Error(),
-			})
-		return nil, fmt.Errorf("failed to connect to Redis cache: %w", err)
-	}
-
-	logger.Info(ctx, "Successfully connected to Redis cache")
-
 	return &RedisCache{
 		client:  client,
 		options: options,
@@ -88,11 +68,50 @@ func (c *RedisCache) buildKey(key string) string {
 	return c.options.KeyPrefix + key
 }
 
+// execute runs fn through the circuit breaker, if one is configured, so that
+// an unavailable Redis fails fast instead of stalling every request. Each
+// command is traced as a child span of ctx.
+func (c *RedisCache) execute(ctx context.Context, operation string, fn func() error) error {
+	_, span := tracer.Start(ctx, "redis."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.DBSystemRedis, semconv.DBOperationName(operation)),
+	)
+
+	var err error
+	if c.options.Breaker == nil {
+		err = fn()
+	} else {
+		err = c.options.Breaker.Execute(fn)
+	}
+
+	tracing.End(span, err)
+	return err
+}
+
 func (c *RedisCache) Get(ctx context.Context, key string) ([]byte, error) {
 	cacheKey := c.buildKey(key)
 
-	val, err := c.client.Get(ctx, cacheKey).Bytes()
-	if err == redis.Nil {
+	var val []byte
+	err := c.execute(ctx, "get", func() error {
+		var err error
+		val, err = c.client.Get(ctx, cacheKey).Bytes()
+		if err == redis.Nil {
+			return nil
+		}
+		return err
+	})
+
+	if errors.Is(err, breaker.ErrCircuitOpen) {
+		c.options.Metrics.ObserveCacheLookup(metrics.CacheTierRedis, false)
+		c.logger.Debug(ctx, "Cache unavailable, treating lookup as a miss",
+			service.Fields{
+				"key": cacheKey,
+			})
+		return nil, nil
+	}
+
+	if err == nil && val == nil {
+		c.options.Metrics.ObserveCacheLookup(metrics.CacheTierRedis, false)
 		c.logger.Debug(ctx, "Cache miss",
 			service.Fields{
 				"key": cacheKey,
@@ -109,6 +128,7 @@ func (c *RedisCache) Get(ctx context.Context, key string) ([]byte, error) {
 		return nil, fmt.Errorf("failed to get value from cache: %w", err)
 	}
 
+	c.options.Metrics.ObserveCacheLookup(metrics.CacheTierRedis, true)
 	c.logger.Debug(ctx, "Cache hit",
 		service.Fields{
 			"key":  cacheKey,
@@ -125,7 +145,18 @@ func (c *RedisCache) Set(ctx context.Context, key string, value []byte, expirati
 		expiration = c.options.DefaultExpiration
 	}
 
-	err := c.client.Set(ctx, cacheKey, value, expiration).Err()
+	err := c.execute(ctx, "set", func() error {
+		return c.client.Set(ctx, cacheKey, value, expiration).Err()
+	})
+
+	if errors.Is(err, breaker.ErrCircuitOpen) {
+		c.logger.Debug(ctx, "Cache unavailable, skipping store",
+			service.Fields{
+				"key": cacheKey,
+			})
+		return fmt.Errorf("failed to set value in cache: %w", err)
+	}
+
 	if err != nil {
 		c.logger.Error(ctx, "Failed to set value in cache",
 			service.Fields{
@@ -149,7 +180,10 @@ func (c *RedisCache) Set(ctx context.Context, key string, value []byte, expirati
 func (c *RedisCache) Delete(ctx context.Context, key string) error {
 	cacheKey := c.buildKey(key)
 
-	err := c.client.Del(ctx, cacheKey).Err()
+	err := c.execute(ctx, "del", func() error {
+		return c.client.Del(ctx, cacheKey).Err()
+	})
+
 	if err != nil {
 		c.logger.Error(ctx, "Failed to delete key from cache",
 			service.Fields{
@@ -166,3 +200,55 @@ func (c *RedisCache) Delete(ctx context.Context, key string) error {
 
 	return nil
 }
+
+func (c *RedisCache) GetDel(ctx context.Context, key string) ([]byte, error) {
+	cacheKey := c.buildKey(key)
+
+	var val []byte
+	err := c.execute(ctx, "getdel", func() error {
+		var err error
+		val, err = c.client.GetDel(ctx, cacheKey).Bytes()
+		if err == redis.Nil {
+			return nil
+		}
+		return err
+	})
+
+	if err != nil {
+		c.logger.Error(ctx, "Failed to take value from cache",
+			service.Fields{
+				"key":   cacheKey,
+				"error": err.Error(),
+			})
+		return nil, fmt.Errorf("failed to take value from cache: %w", err)
+	}
+
+	c.options.Metrics.ObserveCacheLookup(metrics.CacheTierRedis, val != nil)
+	c.logger.Debug(ctx, "Key taken from cache",
+		service.Fields{
+			"key":   cacheKey,
+			"found": val != nil,
+		})
+
+	return val, nil
+}
+
+// publish broadcasts message to every subscriber of channel.
+func (c *RedisCache) publish(ctx context.Context, channel, message string) error {
+	return c.execute(ctx, "publish", func() error {
+		return c.client.Publish(ctx, c.buildKey(channel), message).Err()
+	})
+}
+
+// pubSub returns a subscription without any channel, which does not connect
+// to Redis until subscribe is called.
+func (c *RedisCache) pubSub() *redis.PubSub {
+	return c.client.Subscribe(context.Background())
+}
+
+// subscribe adds channel to pubsub.
+func (c *RedisCache) subscribe(ctx context.Context, pubsub *redis.PubSub, channel string) error {
+	return c.execute(ctx, "subscribe", func() error {
+		return pubsub.Subscribe(ctx, c.buildKey(channel))
+	})
+}
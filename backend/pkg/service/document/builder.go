@@ -21,6 +21,7 @@ import (
 	"context"
 	"encoding/json"
 
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/crypto"
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/service"
 )
 
@@ -67,10 +68,15 @@ func (s *builderService) Build(
 	ext := s.formatManager.GetFileExt(title)
 
 	s.logger.Debug(ctx, "Document info", service.Fields{"title": title, "extension": ext})
-	key, err := s.keyGenerator.Generate(ctx, configurer)
-	if err != nil {
-		s.logger.Error(ctx, "Failed to generate key", service.Fields{"error": err.Error()})
-		return nil, err
+	key := options.documentKey
+	if key == "" {
+		generated, err := s.keyGenerator.Generate(ctx, configurer)
+		if err != nil {
+			s.logger.Error(ctx, "Failed to generate key", service.Fields{"error": err.Error()})
+			return nil, err
+		}
+
+		key = generated
 	}
 
 	format, exists := s.formatManager.GetFormatByName(ext)
@@ -118,7 +124,7 @@ func (s *builderService) Build(
 
 	if len(options.key) > 0 {
 		s.logger.Debug(ctx, "Signing configuration")
-		if err := s.signConfig(config, options.key); err != nil {
+		if err := s.signConfig(config, options.key, options.algorithm); err != nil {
 			s.logger.Error(ctx, "Failed to sign configuration", service.Fields{"error": err.Error()})
 			return nil, err
 		}
@@ -128,7 +134,7 @@ func (s *builderService) Build(
 	return config, nil
 }
 
-func (s *builderService) signConfig(config *Config, secret []byte) error {
+func (s *builderService) signConfig(config *Config, secret []byte, algorithm crypto.Algorithm) error {
 	s.logger.Debug(context.Background(), "Signing config")
 	buf, err := json.Marshal(config)
 	if err != nil {
@@ -136,7 +142,7 @@ func (s *builderService) signConfig(config *Config, secret []byte) error {
 		return err
 	}
 
-	token, err := s.signatureGenerator.Sign(secret, buf)
+	token, err := s.signatureGenerator.Sign(secret, buf, algorithm)
 	if err != nil {
 		s.logger.Error(context.Background(), "Failed to sign config", service.Fields{"error": err.Error()})
 		return err
@@ -21,13 +21,14 @@ import (
 	"context"
 	"crypto/hmac"
 	"crypto/md5"
-	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
 	"time"
 
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/crypto"
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/service"
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/common"
+	jwt "github.com/golang-jwt/jwt/v5"
 )
 
 type KeyGenerator interface {
@@ -63,7 +64,7 @@ func (g *modificationKeyGenerator) Generate(ctx context.Context, configurer Docu
 }
 
 type SignatureGenerator interface {
-	Sign(key []byte, payload []byte) (string, error)
+	Sign(key []byte, payload []byte, algorithm crypto.Algorithm) (string, error)
 }
 
 type jwtSignatureGenerator struct {
@@ -76,12 +77,14 @@ func NewJwtSignatureGenerator(logger service.Logger) SignatureGenerator {
 	}
 }
 
-func (g *jwtSignatureGenerator) Sign(key []byte, payload []byte) (string, error) {
+func (g *jwtSignatureGenerator) Sign(key []byte, payload []byte, algorithm crypto.Algorithm) (string, error) {
+	method := algorithm.SigningMethod()
 	g.logger.Debug(context.Background(), "Generating JWT signature", service.Fields{
 		"payloadSize": len(payload),
+		"algorithm":   method.Alg(),
 	})
 
-	header := `{"alg":"HS256","typ":"JWT"}`
+	header := common.Concat(`{"alg":"`, method.Alg(), `","typ":"JWT"}`)
 	hencoded := base64.RawURLEncoding.EncodeToString([]byte(header))
 
 	pencoded, err := encodeClaims(payload)
@@ -93,7 +96,7 @@ func (g *jwtSignatureGenerator) Sign(key []byte, payload []byte) (string, error)
 	}
 
 	token := common.Concat(hencoded, ".", pencoded)
-	signature := computeHMAC(token, key)
+	signature := computeHMAC(token, key, method)
 
 	jwt := common.Concat(token, ".", signature)
 	g.logger.Debug(context.Background(), "Generated JWT token", service.Fields{
@@ -121,8 +124,8 @@ func encodeClaims(payload []byte) (string, error) {
 	return base64.RawURLEncoding.EncodeToString(npayload), nil
 }
 
-func computeHMAC(message string, key []byte) string {
-	mac := hmac.New(sha256.New, key)
+func computeHMAC(message string, key []byte, method *jwt.SigningMethodHMAC) string {
+	mac := hmac.New(method.Hash.New, key)
 	mac.Write([]byte(message))
 
 	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
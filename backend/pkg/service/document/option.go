@@ -17,8 +17,12 @@
  */
 package document
 
+import "github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/crypto"
+
 type BuilderOptions struct {
 	key            []byte
+	algorithm      crypto.Algorithm
+	documentKey    string
 	userConfigurer UserConfigurer
 	mode           EditorMode
 }
@@ -33,6 +37,20 @@ func WithKey(val []byte) BuilderOption {
 	}
 }
 
+func WithAlgorithm(val crypto.Algorithm) BuilderOption {
+	return func(o *BuilderOptions) {
+		o.algorithm = val
+	}
+}
+
+// WithDocumentKey overrides the generated document key, e.g. to join an
+// editing session that is already open.
+func WithDocumentKey(val string) BuilderOption {
+	return func(o *BuilderOptions) {
+		o.documentKey = val
+	}
+}
+
 func WithUserConfigurer(val UserConfigurer) BuilderOption {
 	return func(o *BuilderOptions) {
 		if val != nil {
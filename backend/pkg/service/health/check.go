@@ -0,0 +1,128 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/deployments"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/client/docserver"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/breaker"
+	pgxpool "github.com/jackc/pgx/v5/pgxpool"
+	redis "github.com/redis/go-redis/v9"
+)
+
+var (
+	ErrSchemaDirty    = errors.New("database schema is dirty")
+	ErrSchemaOutdated = errors.New("database schema is behind the embedded migrations")
+)
+
+// PostgresCheck pings the connection pool.
+func PostgresCheck(pool *pgxpool.Pool) Check {
+	return Check{
+		Name:     "postgres",
+		Critical: true,
+		Run: func(ctx context.Context) (map[string]string, error) {
+			stat := pool.Stat()
+			details := map[string]string{
+				"total_conns":    strconv.Itoa(int(stat.TotalConns())),
+				"acquired_conns": strconv.Itoa(int(stat.AcquiredConns())),
+			}
+
+			return details, pool.Ping(ctx)
+		},
+	}
+}
+
+// MigrationCheck verifies that the database schema is clean and not older
+// than the newest migration embedded in the binary.
+func MigrationCheck(pool *pgxpool.Pool) (Check, error) {
+	latest, err := deployments.LatestVersion()
+	if err != nil {
+		return Check{}, err
+	}
+
+	return Check{
+		Name:     "migrations",
+		Critical: true,
+		Run: func(ctx context.Context) (map[string]string, error) {
+			version, dirty, err := deployments.SchemaVersion(ctx, pool)
+			if err != nil {
+				return nil, err
+			}
+
+			details := map[string]string{
+				"version": strconv.FormatUint(uint64(version), 10),
+				"latest":  strconv.FormatUint(uint64(latest), 10),
+			}
+
+			if dirty {
+				return details, ErrSchemaDirty
+			}
+
+			if version < latest {
+				return details, ErrSchemaOutdated
+			}
+
+			return details, nil
+		},
+	}, nil
+}
+
+// RedisCheck pings Redis and reports the state of its circuit breaker. Redis
+// is not critical, as the cache and the rate limiter degrade to local state
+// while it is unavailable.
+func RedisCheck(client redis.UniversalClient, cb *breaker.Breaker) Check {
+	return Check{
+		Name:     "redis",
+		Critical: false,
+		Run: func(ctx context.Context) (map[string]string, error) {
+			var details map[string]string
+			if cb != nil {
+				details = map[string]string{"breaker": cb.State().String()}
+			}
+
+			return details, client.Ping(ctx).Err()
+		},
+	}
+}
+
+// DocServerCheck requests the version of a Document Server, authorized by
+// the given JWT.
+func DocServerCheck(name string, client docserver.Client, address, header, token string) Check {
+	return Check{
+		Name:     name,
+		Critical: false,
+		Run: func(ctx context.Context) (map[string]string, error) {
+			response, err := client.GetServerVersion(ctx, address,
+				docserver.WithHeader(header), docserver.WithToken("Bearer "+token))
+			if err != nil {
+				return nil, err
+			}
+
+			if response.Error != 0 {
+				return nil, fmt.Errorf("received non-zero error code from docserver: %d", response.Error)
+			}
+
+			return map[string]string{"version": response.Version}, nil
+		},
+	}
+}
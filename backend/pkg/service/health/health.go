@@ -0,0 +1,170 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/service"
+	singleflight "golang.org/x/sync/singleflight"
+)
+
+const (
+	StatusUp       = "UP"
+	StatusDegraded = "DEGRADED"
+	StatusDown     = "DOWN"
+)
+
+// CheckFunc probes a single dependency. The returned details are reported
+// along with the status of the component and must not contain secrets.
+type CheckFunc func(ctx context.Context) (map[string]string, error)
+
+// Check is a named probe of a dependency. A failing critical check makes the
+// service unready, any other failing check only degrades it.
+type Check struct {
+	Name     string
+	Critical bool
+	Run      CheckFunc
+}
+
+// ComponentReport is the outcome of a single check. Errors are logged rather
+// than reported, as they may reveal internal addresses.
+type ComponentReport struct {
+	Status    string            `json:"status"`
+	LatencyMs float64           `json:"latency_ms"`
+	Details   map[string]string `json:"details,omitempty"`
+}
+
+// Report is the outcome of all checks.
+type Report struct {
+	Status     string                     `json:"status"`
+	CheckedAt  time.Time                  `json:"checked_at"`
+	Components map[string]ComponentReport `json:"components"`
+}
+
+// Ready reports whether the service can take traffic.
+func (r *Report) Ready() bool {
+	return r.Status != StatusDown
+}
+
+// Checker runs the readiness checks concurrently and reuses the report for
+// a short while, so that a burst of probes reaches the dependencies once.
+type Checker struct {
+	checks  []Check
+	ttl     time.Duration
+	timeout time.Duration
+	logger  service.Logger
+
+	group   singleflight.Group
+	mu      sync.Mutex
+	report  *Report
+	expires time.Time
+}
+
+func NewChecker(ttl, timeout time.Duration, logger service.Logger, checks ...Check) *Checker {
+	return &Checker{
+		checks:  checks,
+		ttl:     ttl,
+		timeout: timeout,
+		logger:  logger,
+	}
+}
+
+// Check returns the latest report, running the checks again once it has
+// expired. Concurrent callers share a single run.
+func (c *Checker) Check(ctx context.Context) *Report {
+	c.mu.Lock()
+	if c.report != nil && time.Now().Before(c.expires) {
+		report := c.report
+		c.mu.Unlock()
+		return report
+	}
+	c.mu.Unlock()
+
+	result, _, _ := c.group.Do("check", func() (any, error) {
+		report := c.run(context.WithoutCancel(ctx))
+
+		c.mu.Lock()
+		c.report = report
+		c.expires = time.Now().Add(c.ttl)
+		c.mu.Unlock()
+
+		return report, nil
+	})
+
+	return result.(*Report)
+}
+
+func (c *Checker) run(ctx context.Context) *Report {
+	report := &Report{
+		Status:     StatusUp,
+		CheckedAt:  time.Now().UTC(),
+		Components: make(map[string]ComponentReport, len(c.checks)),
+	}
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+
+	for _, check := range c.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			cctx, cancel := context.WithTimeout(ctx, c.timeout)
+			defer cancel()
+
+			start := time.Now()
+			details, err := check.Run(cctx)
+			component := ComponentReport{
+				Status:    StatusUp,
+				LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+				Details:   details,
+			}
+
+			if err != nil {
+				component.Status = StatusDown
+				c.logger.Warn(ctx, "Health check failed", service.Fields{
+					"component": check.Name,
+					"critical":  check.Critical,
+					"error":     err.Error(),
+				})
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			report.Components[check.Name] = component
+			if err == nil {
+				return
+			}
+
+			if check.Critical {
+				report.Status = StatusDown
+			} else if report.Status == StatusUp {
+				report.Status = StatusDegraded
+			}
+		}()
+	}
+
+	wg.Wait()
+	return report
+}
@@ -0,0 +1,74 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package kms
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/config"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/crypto"
+)
+
+type fileKeyManager struct {
+	keyring crypto.Cipher
+}
+
+// NewFileKeyManager returns a local stand-in for a key-management service
+// that wraps data keys with key encryption keys read from a file. The file
+// holds one key per line: the first line wraps new data keys and the
+// remaining lines are retired keys still accepted for unwrapping.
+func NewFileKeyManager(config *config.EncryptionConfig) (crypto.KeyManager, error) {
+	data, err := os.ReadFile(config.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+
+	var keys [][]byte
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		if key := bytes.TrimSpace(scanner.Bytes()); len(key) > 0 {
+			keys = append(keys, bytes.Clone(key))
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil, ErrKeyFileEmpty
+	}
+
+	return &fileKeyManager{
+		keyring: crypto.NewKeyring(keys[0], keys[1:]...),
+	}, nil
+}
+
+func (m *fileKeyManager) WrapKey(ctx context.Context, key []byte) (string, error) {
+	return m.keyring.Encrypt(base64.StdEncoding.EncodeToString(key))
+}
+
+func (m *fileKeyManager) UnwrapKey(ctx context.Context, wrapped string) ([]byte, error) {
+	encoded, err := m.keyring.Decrypt(wrapped)
+	if err != nil {
+		return nil, err
+	}
+
+	return base64.StdEncoding.DecodeString(encoded)
+}
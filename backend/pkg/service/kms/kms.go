@@ -0,0 +1,36 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package kms
+
+import (
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/config"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/crypto"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/service"
+)
+
+// NewKeyManager returns the KeyManager of the configured envelope provider.
+func NewKeyManager(encryption *config.EncryptionConfig, logger service.Logger) (crypto.KeyManager, error) {
+	switch encryption.Provider {
+	case config.EncryptionProviderFile:
+		return NewFileKeyManager(encryption)
+	case config.EncryptionProviderVault:
+		return NewVaultKeyManager(encryption, logger)
+	default:
+		return nil, ErrUnsupportedProvider
+	}
+}
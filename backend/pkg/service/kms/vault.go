@@ -0,0 +1,133 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package kms
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/config"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/crypto"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/service"
+)
+
+type vaultKeyManager struct {
+	config     *config.EncryptionConfig
+	httpClient *http.Client
+	logger     service.Logger
+}
+
+// NewVaultKeyManager returns a KeyManager backed by the HashiCorp Vault
+// transit secrets engine or any service compatible with its HTTP API.
+func NewVaultKeyManager(config *config.EncryptionConfig, logger service.Logger) (crypto.KeyManager, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &vaultKeyManager{
+		config: config,
+		httpClient: &http.Client{
+			Timeout: config.Timeout,
+		},
+		logger: logger,
+	}, nil
+}
+
+func (m *vaultKeyManager) buildURL(operation string) string {
+	return fmt.Sprintf("%s/v1/%s/%s/%s",
+		m.config.VaultAddress,
+		url.PathEscape(m.config.VaultMount),
+		operation,
+		url.PathEscape(m.config.VaultKey),
+	)
+}
+
+func (m *vaultKeyManager) doRequest(ctx context.Context, operation string, body, response any) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.buildURL(operation), bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Vault-Token", m.config.VaultToken)
+
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		m.logger.Error(ctx, "Failed to send vault transit request", service.Fields{
+			"operation": operation,
+			"error":     err.Error(),
+		})
+		return fmt.Errorf("%w: %w", ErrVaultRequestFailed, err)
+	}
+
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		m.logger.Error(ctx, "Vault transit request failed", service.Fields{
+			"operation":   operation,
+			"status_code": resp.StatusCode,
+		})
+		return fmt.Errorf("%w: status %d", ErrVaultRequestFailed, resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidVaultResponse, err)
+	}
+
+	return nil
+}
+
+func (m *vaultKeyManager) WrapKey(ctx context.Context, key []byte) (string, error) {
+	var response vaultEncryptResponse
+	if err := m.doRequest(ctx, "encrypt", vaultEncryptRequest{
+		Plaintext: base64.StdEncoding.EncodeToString(key),
+	}, &response); err != nil {
+		return "", err
+	}
+
+	if response.Data.Ciphertext == "" {
+		return "", ErrInvalidVaultResponse
+	}
+
+	return response.Data.Ciphertext, nil
+}
+
+func (m *vaultKeyManager) UnwrapKey(ctx context.Context, wrapped string) ([]byte, error) {
+	var response vaultDecryptResponse
+	if err := m.doRequest(ctx, "decrypt", vaultDecryptRequest{
+		Ciphertext: wrapped,
+	}, &response); err != nil {
+		return nil, err
+	}
+
+	key, err := base64.StdEncoding.DecodeString(response.Data.Plaintext)
+	if err != nil || len(key) == 0 {
+		return nil, ErrInvalidVaultResponse
+	}
+
+	return key, nil
+}
@@ -0,0 +1,122 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package lock
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/service"
+)
+
+type memoryLock struct {
+	owner     string
+	expiresAt time.Time
+}
+
+// MemoryLocker serializes work within a single process. It offers the same
+// expiring semantics as RedisLocker but does not coordinate replicas.
+type MemoryLocker struct {
+	mu      sync.Mutex
+	locks   map[string]memoryLock
+	options *LockOptions
+	logger  service.Logger
+}
+
+func NewMemoryLocker(logger service.Logger, opts ...Option) (*MemoryLocker, error) {
+	options := DefaultLockOptions()
+
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	if err := options.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid lock options: %w", err)
+	}
+
+	return &MemoryLocker{
+		locks:   make(map[string]memoryLock),
+		options: options,
+		logger:  logger,
+	}, nil
+}
+
+func (l *MemoryLocker) tryLock(lockKey, owner string, ttl time.Duration) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if current, ok := l.locks[lockKey]; ok && now.Before(current.expiresAt) {
+		return false
+	}
+
+	l.locks[lockKey] = memoryLock{
+		owner:     owner,
+		expiresAt: now.Add(ttl),
+	}
+
+	return true
+}
+
+func (l *MemoryLocker) Lock(ctx context.Context, key string, ttl time.Duration) (service.Unlock, error) {
+	lockKey := l.options.KeyPrefix + key
+
+	owner, err := generateOwner()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate lock owner: %w", err)
+	}
+
+	ticker := time.NewTicker(l.options.RetryInterval)
+	defer ticker.Stop()
+
+	for {
+		if l.tryLock(lockKey, owner, ttl) {
+			l.logger.Debug(ctx, "Lock acquired",
+				service.Fields{
+					"key": lockKey,
+					"ttl": ttl.String(),
+				})
+			return func(ctx context.Context) error {
+				return l.release(ctx, lockKey, owner)
+			}, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w: %w", ErrLockNotAcquired, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+func (l *MemoryLocker) release(ctx context.Context, lockKey, owner string) error {
+	l.mu.Lock()
+	if current, ok := l.locks[lockKey]; ok && current.owner == owner {
+		delete(l.locks, lockKey)
+	}
+	l.mu.Unlock()
+
+	l.logger.Debug(ctx, "Lock released",
+		service.Fields{
+			"key": lockKey,
+		})
+
+	return nil
+}
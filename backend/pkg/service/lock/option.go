@@ -0,0 +1,57 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package lock
+
+import (
+	"fmt"
+	"time"
+)
+
+type LockOptions struct {
+	KeyPrefix     string
+	RetryInterval time.Duration
+}
+
+func DefaultLockOptions() *LockOptions {
+	return &LockOptions{
+		KeyPrefix:     "app:lock:",
+		RetryInterval: 100 * time.Millisecond,
+	}
+}
+
+func (o *LockOptions) Validate() error {
+	if o.RetryInterval <= 0 {
+		return fmt.Errorf("retry interval must be positive")
+	}
+
+	return nil
+}
+
+type Option func(*LockOptions)
+
+func WithKeyPrefix(prefix string) Option {
+	return func(o *LockOptions) {
+		o.KeyPrefix = prefix
+	}
+}
+
+func WithRetryInterval(interval time.Duration) Option {
+	return func(o *LockOptions) {
+		o.RetryInterval = interval
+	}
+}
@@ -0,0 +1,132 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package lock
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/service"
+	redis "github.com/redis/go-redis/v9"
+)
+
+// releaseScript deletes the lock only while it is still owned by the caller,
+// so an expired lock taken over by another replica is never released.
+const releaseScript = `
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+    return redis.call('DEL', KEYS[1])
+end
+return 0
+`
+
+type RedisLocker struct {
+	client        redis.UniversalClient
+	options       *LockOptions
+	logger        service.Logger
+	releaseScript *redis.Script
+}
+
+func NewRedisLocker(client redis.UniversalClient, logger service.Logger, opts ...Option) (*RedisLocker, error) {
+	options := DefaultLockOptions()
+
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	if err := options.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid lock options: %w", err)
+	}
+
+	return &RedisLocker{
+		client:        client,
+		options:       options,
+		logger:        logger,
+		releaseScript: redis.NewScript(releaseScript),
+	}, nil
+}
+
+func (l *RedisLocker) Lock(ctx context.Context, key string, ttl time.Duration) (service.Unlock, error) {
+	lockKey := l.options.KeyPrefix + key
+
+	owner, err := generateOwner()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate lock owner: %w", err)
+	}
+
+	ticker := time.NewTicker(l.options.RetryInterval)
+	defer ticker.Stop()
+
+	for {
+		acquired, err := l.client.SetNX(ctx, lockKey, owner, ttl).Result()
+		if err != nil && ctx.Err() == nil {
+			l.logger.Error(ctx, "Failed to acquire lock",
+				service.Fields{
+					"key":   lockKey,
+					"error": err.Error(),
+				})
+			return nil, fmt.Errorf("failed to acquire lock: %w", err)
+		}
+
+		if acquired {
+			l.logger.Debug(ctx, "Lock acquired",
+				service.Fields{
+					"key": lockKey,
+					"ttl": ttl.String(),
+				})
+			return func(ctx context.Context) error {
+				return l.release(ctx, lockKey, owner)
+			}, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w: %w", ErrLockNotAcquired, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+func (l *RedisLocker) release(ctx context.Context, lockKey, owner string) error {
+	if err := l.releaseScript.Run(ctx, l.client, []string{lockKey}, owner).Err(); err != nil {
+		l.logger.Error(ctx, "Failed to release lock",
+			service.Fields{
+				"key":   lockKey,
+				"error": err.Error(),
+			})
+		return fmt.Errorf("failed to release lock: %w", err)
+	}
+
+	l.logger.Debug(ctx, "Lock released",
+		service.Fields{
+			"key": lockKey,
+		})
+
+	return nil
+}
+
+func generateOwner() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(buf), nil
+}
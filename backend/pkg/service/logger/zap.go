@@ -23,6 +23,7 @@ import (
 
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/config"
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/service"
+	"go.opentelemetry.io/otel/trace"
 	zap "go.uber.org/zap"
 	zapcore "go.uber.org/zap/zapcore"
 )
@@ -104,7 +105,12 @@ func extractContextFields(ctx context.Context) []zap.Field {
 		return fields
 	}
 
-	if traceID := ctx.Value("trace_id"); traceID != nil {
+	if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+		fields = append(fields,
+			zap.String("trace_id", span.TraceID().String()),
+			zap.String("span_id", span.SpanID().String()),
+		)
+	} else if traceID := ctx.Value("trace_id"); traceID != nil {
 		fields = append(fields, zap.String("trace_id", traceID.(string)))
 	}
 
@@ -0,0 +1,91 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package metrics
+
+import (
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/breaker"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var breakerStates = []string{
+	breaker.StateClosed.String(),
+	breaker.StateOpen.String(),
+	breaker.StateHalfOpen.String(),
+}
+
+// BreakerStatusProvider reports the state of a set of circuit breakers.
+type BreakerStatusProvider interface {
+	Statuses() []breaker.Status
+}
+
+type breakerCollector struct {
+	provider BreakerStatusProvider
+	state    *prometheus.Desc
+	inFlight *prometheus.Desc
+}
+
+// RegisterBreakers exports the state and in-flight calls of circuit breakers,
+// read from provider on every scrape. Breakers are aggregated per registry
+// so that the series count stays bounded and hostnames are not exposed.
+func (m *Metrics) RegisterBreakers(provider BreakerStatusProvider) error {
+	return m.Register(newBreakerCollector(provider))
+}
+
+func newBreakerCollector(provider BreakerStatusProvider) prometheus.Collector {
+	return &breakerCollector{
+		provider: provider,
+		state: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "circuit_breaker", "state"),
+			"Number of circuit breakers of a registry in each state.",
+			[]string{"name", "state"}, nil,
+		),
+		inFlight: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "circuit_breaker", "in_flight"),
+			"Calls currently admitted by the bulkheads of a registry.",
+			[]string{"name"}, nil,
+		),
+	}
+}
+
+func (c *breakerCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.state
+	ch <- c.inFlight
+}
+
+func (c *breakerCollector) Collect(ch chan<- prometheus.Metric) {
+	var names []string
+	states := make(map[string]map[string]int)
+	inFlight := make(map[string]int)
+	for _, status := range c.provider.Statuses() {
+		if _, ok := states[status.Name]; !ok {
+			names = append(names, status.Name)
+			states[status.Name] = make(map[string]int, len(breakerStates))
+		}
+
+		states[status.Name][status.State]++
+		inFlight[status.Name] += status.InFlight
+	}
+
+	for _, name := range names {
+		for _, state := range breakerStates {
+			ch <- prometheus.MustNewConstMetric(c.state, prometheus.GaugeValue, float64(states[name][state]), name, state)
+		}
+
+		ch <- prometheus.MustNewConstMetric(c.inFlight, prometheus.GaugeValue, float64(inFlight[name]), name)
+	}
+}
@@ -0,0 +1,228 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "onlyoffice_miro"
+
+const (
+	ClientMiro      = "miro"
+	ClientDocServer = "docserver"
+)
+
+const (
+	CacheTierMemory = "memory"
+	CacheTierRedis  = "redis"
+)
+
+const (
+	OutcomeSuccess  = "success"
+	OutcomeFailure  = "failure"
+	OutcomeRejected = "rejected"
+)
+
+const (
+	// maxCallbackStatus is the highest status documented for Document Server
+	// callbacks.
+	maxCallbackStatus   = 7
+	callbackStatusOther = "other"
+)
+
+// Metrics collects the application metrics exposed to Prometheus. A nil
+// *Metrics discards every observation, so instrumented components work
+// unchanged when metrics are disabled.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests     *prometheus.HistogramVec
+	upstreamRequests *prometheus.HistogramVec
+	upstreamErrors   *prometheus.CounterVec
+	cacheLookups     *prometheus.CounterVec
+	oauthRefreshes   *prometheus.CounterVec
+	callbacks        *prometheus.CounterVec
+	uploads          *prometheus.CounterVec
+	rateLimitDenials *prometheus.CounterVec
+}
+
+// NewMetrics creates the application metrics along with the Go runtime and
+// process collectors, registered on a dedicated registry.
+func NewMetrics() (*Metrics, error) {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Latency of HTTP requests by route and response status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		upstreamRequests: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "upstream_request_duration_seconds",
+			Help:      "Latency of Miro and Document Server calls by operation.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"client", "operation"}),
+		upstreamErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "upstream_request_errors_total",
+			Help:      "Failed Miro and Document Server calls by operation.",
+		}, []string{"client", "operation"}),
+		cacheLookups: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "cache_lookups_total",
+			Help:      "Cache lookups by tier and result.",
+		}, []string{"tier", "result"}),
+		oauthRefreshes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "oauth_refreshes_total",
+			Help:      "OAuth token refreshes by outcome.",
+		}, []string{"outcome"}),
+		callbacks: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "callbacks_total",
+			Help:      "Document Server callbacks received by status.",
+		}, []string{"status"}),
+		uploads: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "uploads_total",
+			Help:      "Saved files uploaded to Miro by upload mode and outcome.",
+		}, []string{"mode", "outcome"}),
+		rateLimitDenials: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "rate_limit_denials_total",
+			Help:      "Requests rejected by the rate limiter by policy.",
+		}, []string{"policy"}),
+	}
+
+	for _, collector := range []prometheus.Collector{
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.upstreamRequests,
+		m.upstreamErrors,
+		m.cacheLookups,
+		m.oauthRefreshes,
+		m.callbacks,
+		m.uploads,
+		m.rateLimitDenials,
+	} {
+		if err := m.registry.Register(collector); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+// Register adds a collector to the metrics registry.
+func (m *Metrics) Register(collector prometheus.Collector) error {
+	if m == nil {
+		return nil
+	}
+
+	return m.registry.Register(collector)
+}
+
+// Handler serves the collected metrics in the Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+func (m *Metrics) ObserveHTTPRequest(method, route string, status int, duration time.Duration) {
+	if m == nil {
+		return
+	}
+
+	m.httpRequests.WithLabelValues(method, route, strconv.Itoa(status)).Observe(duration.Seconds())
+}
+
+func (m *Metrics) ObserveUpstream(client, operation string, duration time.Duration, err error) {
+	if m == nil {
+		return
+	}
+
+	m.upstreamRequests.WithLabelValues(client, operation).Observe(duration.Seconds())
+	if err != nil {
+		m.upstreamErrors.WithLabelValues(client, operation).Inc()
+	}
+}
+
+func (m *Metrics) ObserveCacheLookup(tier string, hit bool) {
+	if m == nil {
+		return
+	}
+
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+
+	m.cacheLookups.WithLabelValues(tier, result).Inc()
+}
+
+func (m *Metrics) ObserveOAuthRefresh(outcome string) {
+	if m == nil {
+		return
+	}
+
+	m.oauthRefreshes.WithLabelValues(outcome).Inc()
+}
+
+// ObserveCallback counts a Document Server callback by status. Statuses
+// outside the documented range share a single label to bound the series.
+func (m *Metrics) ObserveCallback(status int) {
+	if m == nil {
+		return
+	}
+
+	label := callbackStatusOther
+	if status >= 0 && status <= maxCallbackStatus {
+		label = strconv.Itoa(status)
+	}
+
+	m.callbacks.WithLabelValues(label).Inc()
+}
+
+func (m *Metrics) ObserveUpload(mode string, err error) {
+	if m == nil {
+		return
+	}
+
+	outcome := OutcomeSuccess
+	if err != nil {
+		outcome = OutcomeFailure
+	}
+
+	m.uploads.WithLabelValues(mode, outcome).Inc()
+}
+
+func (m *Metrics) ObserveRateLimitDenial(policy string) {
+	if m == nil {
+		return
+	}
+
+	m.rateLimitDenials.WithLabelValues(policy).Inc()
+}
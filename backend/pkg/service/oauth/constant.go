@@ -0,0 +1,51 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package oauth
+
+import "time"
+
+const (
+	// refreshBatchSize is the number of tokens listed per page in a refresh run.
+	refreshBatchSize = 100
+	// maxRefreshRejections is the number of consecutive rejected refreshes
+	// after which a token is revoked and deleted.
+	maxRefreshRejections = 3
+	// errorInvalidGrant is the RFC 6749 error code sent for a refresh token
+	// that is expired, revoked or otherwise unusable.
+	errorInvalidGrant = "invalid_grant"
+	// errorInvalidClient and errorUnauthorizedClient are RFC 6749 error codes
+	// sent when the client credentials themselves are refused.
+	errorInvalidClient      = "invalid_client"
+	errorUnauthorizedClient = "unauthorized_client"
+	// refreshLockKey identifies the lock held while a token is being refreshed.
+	refreshLockKey = "oauth_refresh:%s:%s"
+	// refreshLockTTL outlives a refresh so that a crashed replica cannot
+	// keep the lock forever.
+	refreshLockTTL = 30 * time.Second
+	// refreshTimeout bounds waiting for the lock and refreshing the token.
+	refreshTimeout = 15 * time.Second
+	// unlockTimeout bounds releasing the refresh lock after the refresh.
+	unlockTimeout = 5 * time.Second
+	// tokenCacheExpiration bounds how long an encrypted token stays cached.
+	tokenCacheExpiration = 5 * time.Minute
+	// stateLifetime bounds how long an installation may take to complete.
+	stateLifetime = 10 * time.Minute
+	// stateNonceSize and pkceVerifierSize are sizes of random values in bytes.
+	stateNonceSize   = 32
+	pkceVerifierSize = 32
+)
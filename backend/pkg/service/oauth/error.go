@@ -20,6 +20,9 @@ package oauth
 import "errors"
 
 var (
-	ErrTokenExpired = errors.New("token has expired")
-	ErrTokenMissing = errors.New("token is missing")
+	ErrTokenExpired  = errors.New("token has expired")
+	ErrTokenMissing  = errors.New("token is missing")
+	ErrTokenRejected = errors.New("refresh token was rejected")
+	ErrInvalidState  = errors.New("authorization state is invalid")
+	ErrStateNotFound = errors.New("authorization state has expired or was already used")
 )
@@ -19,11 +19,27 @@ package oauth
 
 import (
 	"context"
+	"time"
 
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/core"
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/core/component"
 )
 
+// TokenStatus describes a stored token without exposing its secrets.
+type TokenStatus struct {
+	TeamID      string                         `json:"team_id"`
+	UserID      string                         `json:"user_id"`
+	Status      component.AuthenticationStatus `json:"status"`
+	ExpiresAt   int                            `json:"expires_at"`
+	RefreshedAt *time.Time                     `json:"refreshed_at,omitempty"`
+}
+
 type OAuthService[T any] interface {
 	Save(ctx context.Context, teamID, userID string, token component.Authentication) error
 	Find(ctx context.Context, teamID, userID string) (component.Authentication, error)
+	Revoke(ctx context.Context, teamID, userID string) error
+	RevokeTeam(ctx context.Context, teamID string) (int, error)
+	RefreshExpiring(ctx context.Context, within time.Duration) (int, error)
+	Statuses(ctx context.Context, status component.AuthenticationStatus, limit int, after *core.AuthCursor) ([]TokenStatus, *core.AuthCursor, error)
+	Reencrypt(ctx context.Context) (int, error)
 }
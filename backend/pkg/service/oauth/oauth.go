@@ -19,7 +19,9 @@ package oauth
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/core"
@@ -27,7 +29,10 @@ import (
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/crypto"
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/service"
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/client/oauth"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/lock"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/metrics"
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/storage/pg"
+	singleflight "golang.org/x/sync/singleflight"
 )
 
 type OAuthable[T any] interface {
@@ -39,6 +44,11 @@ type oauthService[T any] struct {
 	oauthClient    oauth.OAuthClient[T]
 	oauthConverter OAuthable[T]
 	storageService service.Storage[core.AuthCompositeKey, component.Authentication]
+	lister         service.Lister[core.AuthCompositeKey, component.Authentication, core.AuthFilter]
+	locker         service.Locker
+	cache          service.Cache
+	metrics        *metrics.Metrics
+	refreshGroup   singleflight.Group
 	logger         service.Logger
 }
 
@@ -47,6 +57,10 @@ func NewOAuthService[T any](
 	oauthClient oauth.OAuthClient[T],
 	oauthConverter OAuthable[T],
 	storageService service.Storage[core.AuthCompositeKey, component.Authentication],
+	lister service.Lister[core.AuthCompositeKey, component.Authentication, core.AuthFilter],
+	locker service.Locker,
+	cache service.Cache,
+	metrics *metrics.Metrics,
 	logger service.Logger,
 ) OAuthService[T] {
 	return &oauthService[T]{
@@ -54,10 +68,80 @@ func NewOAuthService[T any](
 		oauthClient:    oauthClient,
 		oauthConverter: oauthConverter,
 		storageService: storageService,
+		lister:         lister,
+		locker:         locker,
+		cache:          cache,
+		metrics:        metrics,
 		logger:         logger,
 	}
 }
 
+func (s *oauthService[T]) buildCacheKey(key core.AuthCompositeKey) string {
+	return fmt.Sprintf("authentication:%s:%s", key.TeamID, key.UserID)
+}
+
+// cacheAuth stores the encrypted form of auth, so tokens are never kept in
+// the cache in plain text.
+func (s *oauthService[T]) cacheAuth(ctx context.Context, key core.AuthCompositeKey, auth component.Authentication) {
+	data, err := json.Marshal(auth)
+	if err != nil {
+		s.logger.Warn(ctx, "Failed to marshal OAuth token for caching", service.Fields{
+			"error":  err.Error(),
+			"teamID": key.TeamID,
+			"userID": key.UserID,
+		})
+		return
+	}
+
+	if err := s.cache.Set(ctx, s.buildCacheKey(key), data, tokenCacheExpiration); err != nil {
+		s.logger.Warn(ctx, "Failed to cache OAuth token", service.Fields{
+			"error":  err.Error(),
+			"teamID": key.TeamID,
+			"userID": key.UserID,
+		})
+	}
+}
+
+func (s *oauthService[T]) invalidateCache(ctx context.Context, key core.AuthCompositeKey) {
+	if err := s.cache.Delete(ctx, s.buildCacheKey(key)); err != nil {
+		s.logger.Warn(ctx, "Failed to invalidate OAuth token cache", service.Fields{
+			"error":  err.Error(),
+			"teamID": key.TeamID,
+			"userID": key.UserID,
+		})
+	}
+}
+
+// findStored returns the encrypted token, preferring the cache over storage.
+func (s *oauthService[T]) findStored(ctx context.Context, key core.AuthCompositeKey) (component.Authentication, error) {
+	data, err := s.cache.Get(ctx, s.buildCacheKey(key))
+	if err != nil {
+		s.logger.Warn(ctx, "Failed to read OAuth token from cache", service.Fields{
+			"error":  err.Error(),
+			"teamID": key.TeamID,
+			"userID": key.UserID,
+		})
+	}
+
+	if data != nil {
+		var auth component.Authentication
+		if err := json.Unmarshal(data, &auth); err == nil {
+			return auth, nil
+		}
+	}
+
+	auth, err := s.storageService.Find(ctx, key)
+	if err != nil {
+		return auth, err
+	}
+
+	if auth.AccessToken != "" {
+		s.cacheAuth(ctx, key, auth)
+	}
+
+	return auth, nil
+}
+
 func (s *oauthService[T]) encryptTokens(token component.Authentication) (string, string, error) {
 	encAccess, err := s.cipher.Encrypt(token.AccessToken)
 	if err != nil {
@@ -93,11 +177,14 @@ func (s *oauthService[T]) createEncryptedAuth(token component.Authentication) (c
 	}
 
 	return component.Authentication{
-		TokenType:    token.TokenType,
-		AccessToken:  encAccess,
-		RefreshToken: encRefresh,
-		ExpiresAt:    token.ExpiresAt,
-		Scope:        token.Scope,
+		TokenType:       token.TokenType,
+		AccessToken:     encAccess,
+		RefreshToken:    encRefresh,
+		ExpiresAt:       token.ExpiresAt,
+		Scope:           token.Scope,
+		Status:          token.Status,
+		RefreshedAt:     token.RefreshedAt,
+		RefreshFailures: token.RefreshFailures,
 	}, nil
 }
 
@@ -108,11 +195,14 @@ func (s *oauthService[T]) createDecryptedAuth(token component.Authentication) (c
 	}
 
 	return component.Authentication{
-		TokenType:    token.TokenType,
-		AccessToken:  decAccess,
-		RefreshToken: decRefresh,
-		ExpiresAt:    token.ExpiresAt,
-		Scope:        token.Scope,
+		TokenType:       token.TokenType,
+		AccessToken:     decAccess,
+		RefreshToken:    decRefresh,
+		ExpiresAt:       token.ExpiresAt,
+		Scope:           token.Scope,
+		Status:          token.Status,
+		RefreshedAt:     token.RefreshedAt,
+		RefreshFailures: token.RefreshFailures,
 	}, nil
 }
 
@@ -132,10 +222,13 @@ func (s *oauthService[T]) Save(ctx context.Context, teamID, userID string, token
 		return err
 	}
 
-	_, err = s.storageService.Insert(ctx, core.AuthCompositeKey{
+	key := core.AuthCompositeKey{
 		TeamID: teamID,
 		UserID: userID,
-	}, auth)
+	}
+
+	_, err = s.storageService.Insert(ctx, key, auth)
+	s.invalidateCache(ctx, key)
 
 	if err != nil {
 		s.logger.Error(ctx, "Failed to save OAuth token", service.Fields{
@@ -164,7 +257,7 @@ func (s *oauthService[T]) Find(ctx context.Context, teamID, userID string) (comp
 		UserID: userID,
 	}
 
-	storedAuth, err := s.storageService.Find(ctx, key)
+	storedAuth, err := s.findStored(ctx, key)
 	if err != nil {
 		if errors.Is(err, pg.ErrNoRowsAffected) {
 			s.logger.Warn(ctx, "OAuth token not found", service.Fields{
@@ -190,6 +283,14 @@ func (s *oauthService[T]) Find(ctx context.Context, teamID, userID string) (comp
 		return storedAuth, ErrTokenMissing
 	}
 
+	if storedAuth.Status == component.AuthenticationStatusRejected {
+		s.logger.Warn(ctx, "OAuth token was rejected and requires reinstallation", service.Fields{
+			"teamID": teamID,
+			"userID": userID,
+		})
+		return component.Authentication{}, ErrTokenMissing
+	}
+
 	if time.Now().Unix() <= int64(storedAuth.ExpiresAt) {
 		s.logger.Info(ctx, "Using existing OAuth token", service.Fields{
 			"teamID":    teamID,
@@ -204,58 +305,509 @@ func (s *oauthService[T]) Find(ctx context.Context, teamID, userID string) (comp
 		"userID": userID,
 	})
 
-	refreshToken, err := s.cipher.Decrypt(storedAuth.RefreshToken)
+	refreshedToken, err := s.refresh(ctx, key, storedAuth)
 	if err != nil {
-		s.logger.Error(ctx, "Failed to decrypt refresh token", service.Fields{
-			"error":  err.Error(),
-			"teamID": teamID,
-			"userID": userID,
-		})
 		return component.Authentication{}, ErrTokenMissing
 	}
 
-	token, err := s.oauthClient.Refresh(ctx, refreshToken)
+	return refreshedToken, nil
+}
+
+// refresh de-duplicates concurrent refreshes of the same token. Callers in
+// this process share a single flight, and replicas serialize on a Redis lock.
+// The flight runs detached from the caller's context so that a refresh token
+// already rotated by Miro is never lost to a cancelled request.
+func (s *oauthService[T]) refresh(
+	ctx context.Context,
+	key core.AuthCompositeKey,
+	storedAuth component.Authentication,
+) (component.Authentication, error) {
+	result, err, shared := s.refreshGroup.Do(key.TeamID+":"+key.UserID, func() (any, error) {
+		fctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), refreshTimeout)
+		defer cancel()
+
+		auth, err := s.refreshExclusively(fctx, key, storedAuth)
+		s.observeRefresh(err)
+
+		return auth, err
+	})
+
+	if shared {
+		s.logger.Debug(ctx, "Shared OAuth token refresh with a concurrent caller", service.Fields{
+			"teamID": key.TeamID,
+			"userID": key.UserID,
+		})
+	}
+
 	if err != nil {
-		s.logger.Error(ctx, "Failed to refresh OAuth token", service.Fields{
+		return component.Authentication{}, err
+	}
+
+	return result.(component.Authentication), nil
+}
+
+// observeRefresh counts the outcome of a refresh flight, once for all the
+// callers sharing it.
+func (s *oauthService[T]) observeRefresh(err error) {
+	switch {
+	case err == nil:
+		s.metrics.ObserveOAuthRefresh(metrics.OutcomeSuccess)
+	case errors.Is(err, ErrTokenRejected):
+		s.metrics.ObserveOAuthRefresh(metrics.OutcomeRejected)
+	default:
+		s.metrics.ObserveOAuthRefresh(metrics.OutcomeFailure)
+	}
+}
+
+func (s *oauthService[T]) refreshExclusively(
+	ctx context.Context,
+	key core.AuthCompositeKey,
+	storedAuth component.Authentication,
+) (component.Authentication, error) {
+	fields := service.Fields{
+		"teamID": key.TeamID,
+		"userID": key.UserID,
+	}
+
+	unlock, err := s.locker.Lock(ctx, fmt.Sprintf(refreshLockKey, key.TeamID, key.UserID), refreshLockTTL)
+	if errors.Is(err, lock.ErrLockNotAcquired) {
+		fields["error"] = err.Error()
+		s.logger.Error(ctx, "Timed out waiting for OAuth refresh lock", fields)
+		return component.Authentication{}, err
+	}
+
+	if err != nil {
+		s.logger.Warn(ctx, "Refreshing OAuth token without a distributed lock", service.Fields{
+			"teamID": key.TeamID,
+			"userID": key.UserID,
 			"error":  err.Error(),
-			"teamID": teamID,
-			"userID": userID,
 		})
-		return component.Authentication{}, ErrTokenMissing
+	} else {
+		defer s.releaseLock(ctx, key, unlock)
+	}
+
+	current, err := s.storageService.Find(ctx, key)
+	if err == nil && s.rotatedMeanwhile(current, storedAuth) {
+		s.logger.Info(ctx, "OAuth token was refreshed by another caller", fields)
+		if current.Status == component.AuthenticationStatusRejected {
+			return component.Authentication{}, ErrTokenRejected
+		}
+
+		return s.createDecryptedAuth(current)
+	}
+
+	return s.refreshToken(ctx, key, storedAuth)
+}
+
+// releaseLock frees a refresh lock even when ctx has already expired, so the
+// lock does not linger until its TTL and stall the next refresh.
+func (s *oauthService[T]) releaseLock(ctx context.Context, key core.AuthCompositeKey, unlock service.Unlock) {
+	uctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), unlockTimeout)
+	defer cancel()
+
+	if err := unlock(uctx); err != nil {
+		s.logger.Warn(ctx, "Failed to release OAuth refresh lock", service.Fields{
+			"teamID": key.TeamID,
+			"userID": key.UserID,
+			"error":  err.Error(),
+		})
+	}
+}
+
+// rotatedMeanwhile reports whether the stored refresh token differs from the
+// one the caller started with. Plaintexts are compared because re-encryption
+// changes the ciphertext without rotating the token.
+func (s *oauthService[T]) rotatedMeanwhile(current, storedAuth component.Authentication) bool {
+	if current.RefreshToken == "" || current.RefreshToken == storedAuth.RefreshToken {
+		return false
+	}
+
+	currentToken, err := s.cipher.Decrypt(current.RefreshToken)
+	if err != nil {
+		return false
+	}
+
+	storedToken, err := s.cipher.Decrypt(storedAuth.RefreshToken)
+	return err != nil || currentToken != storedToken
+}
+
+func (s *oauthService[T]) refreshToken(
+	ctx context.Context,
+	key core.AuthCompositeKey,
+	storedAuth component.Authentication,
+) (component.Authentication, error) {
+	fields := service.Fields{
+		"teamID": key.TeamID,
+		"userID": key.UserID,
+	}
+
+	refreshToken, err := s.cipher.Decrypt(storedAuth.RefreshToken)
+	if err != nil {
+		fields["error"] = err.Error()
+		s.logger.Error(ctx, "Failed to decrypt refresh token", fields)
+		return component.Authentication{}, err
+	}
+
+	token, err := s.oauthClient.Refresh(ctx, refreshToken)
+	if err != nil {
+		fields["error"] = err.Error()
+		s.logger.Error(ctx, "Failed to refresh OAuth token", fields)
+
+		// Only a dead grant counts towards revocation. Misconfigured client
+		// credentials and transient failures leave the stored token intact.
+		if isClientMisconfigured(err) {
+			s.logger.Error(ctx, "OAuth client credentials were refused, check the OAuth configuration", fields)
+			return component.Authentication{}, err
+		}
+
+		if !isRefreshRejected(err) {
+			return component.Authentication{}, err
+		}
+
+		storedAuth.RefreshFailures++
+		if storedAuth.RefreshFailures >= maxRefreshRejections {
+			s.logger.Warn(ctx, "OAuth refresh token was repeatedly rejected, revoking", fields)
+			if err := s.revoke(ctx, key, storedAuth); err != nil {
+				return component.Authentication{}, err
+			}
+
+			return component.Authentication{}, ErrTokenRejected
+		}
+
+		storedAuth.Status = component.AuthenticationStatusRejected
+		_, uerr := s.storageService.Update(ctx, key, storedAuth)
+		s.invalidateCache(ctx, key)
+		if uerr != nil {
+			fields["error"] = uerr.Error()
+			s.logger.Error(ctx, "Failed to mark OAuth token as rejected", fields)
+		} else {
+			s.logger.Warn(ctx, "OAuth refresh token was rejected", fields)
+		}
+
+		return component.Authentication{}, ErrTokenRejected
 	}
 
 	refreshedToken, err := s.oauthConverter.Convert(token)
 	if err != nil {
-		s.logger.Error(ctx, "Failed to convert refreshed OAuth token", service.Fields{
+		fields["error"] = err.Error()
+		s.logger.Error(ctx, "Failed to convert refreshed OAuth token", fields)
+		return component.Authentication{}, err
+	}
+
+	now := time.Now()
+	refreshedToken.Status = component.AuthenticationStatusActive
+	refreshedToken.RefreshedAt = &now
+	refreshedToken.RefreshFailures = 0
+
+	updatedAuth, err := s.createEncryptedAuth(refreshedToken)
+	if err != nil {
+		fields["error"] = err.Error()
+		s.logger.Error(ctx, "Failed to encrypt refreshed OAuth token", fields)
+		return component.Authentication{}, err
+	}
+
+	if _, err = s.storageService.Update(ctx, key, updatedAuth); err != nil {
+		fields["error"] = err.Error()
+		s.logger.Error(ctx, "Failed to update OAuth token in storage", fields)
+		s.invalidateCache(ctx, key)
+		return component.Authentication{}, err
+	}
+
+	s.cacheAuth(ctx, key, updatedAuth)
+
+	s.logger.Info(ctx, "Successfully refreshed and updated OAuth token", fields)
+	return refreshedToken, nil
+}
+
+func (s *oauthService[T]) RefreshExpiring(ctx context.Context, within time.Duration) (int, error) {
+	found, refreshed, err := s.refreshAll(ctx, core.AuthFilter{
+		ExpiresBefore: int(time.Now().Add(within).Unix()),
+		Status:        string(component.AuthenticationStatusActive),
+	})
+	if err != nil {
+		s.logger.Error(ctx, "Failed to refresh expiring OAuth tokens", service.Fields{
+			"error": err.Error(),
+		})
+		return refreshed, err
+	}
+
+	// Rejected tokens are retried until they either recover or exceed
+	// maxRefreshRejections and get revoked.
+	rejectedFound, rejectedRefreshed, err := s.refreshAll(ctx, core.AuthFilter{
+		Status: string(component.AuthenticationStatusRejected),
+	})
+	found += rejectedFound
+	refreshed += rejectedRefreshed
+	if err != nil {
+		s.logger.Error(ctx, "Failed to refresh rejected OAuth tokens", service.Fields{
+			"error": err.Error(),
+		})
+		return refreshed, err
+	}
+
+	s.logger.Info(ctx, "Expiring OAuth tokens processed", service.Fields{
+		"found":     found,
+		"refreshed": refreshed,
+	})
+	return refreshed, nil
+}
+
+// refreshAll pages through every token matching the filter. Paging resumes
+// past the last token seen, so tokens that keep failing cannot starve the
+// ones listed after them. A refreshed token moves to a later expiry and may
+// be listed again, so tokens refreshed since the run started are skipped.
+func (s *oauthService[T]) refreshAll(ctx context.Context, filter core.AuthFilter) (int, int, error) {
+	filter.Limit = refreshBatchSize
+	started := time.Now()
+
+	found, refreshed := 0, 0
+	for {
+		records, err := s.lister.List(ctx, filter)
+		if err != nil {
+			return found, refreshed, err
+		}
+
+		for _, record := range records {
+			if ctx.Err() != nil {
+				return found, refreshed, ctx.Err()
+			}
+
+			if record.Value.RefreshedAt != nil && record.Value.RefreshedAt.After(started) {
+				continue
+			}
+
+			found++
+			if record.Value.RefreshToken == "" {
+				continue
+			}
+
+			if _, err := s.refresh(ctx, record.Key, record.Value); err == nil {
+				refreshed++
+			}
+		}
+
+		if len(records) < refreshBatchSize {
+			return found, refreshed, nil
+		}
+
+		last := records[len(records)-1]
+		filter.After = &core.AuthCursor{
+			ExpiresAt: last.Value.ExpiresAt,
+			Key:       last.Key,
+		}
+	}
+}
+
+// Statuses returns up to limit tokens listed after the cursor, along with the
+// cursor of the next page or nil once the listing is exhausted.
+func (s *oauthService[T]) Statuses(
+	ctx context.Context,
+	status component.AuthenticationStatus,
+	limit int,
+	after *core.AuthCursor,
+) ([]TokenStatus, *core.AuthCursor, error) {
+	records, err := s.lister.List(ctx, core.AuthFilter{
+		Status: string(status),
+		Limit:  limit,
+		After:  after,
+	})
+	if err != nil {
+		s.logger.Error(ctx, "Failed to list OAuth token statuses", service.Fields{
 			"error":  err.Error(),
-			"teamID": teamID,
-			"userID": userID,
+			"status": status,
 		})
-		return component.Authentication{}, ErrTokenMissing
+		return nil, nil, err
 	}
 
-	updatedAuth, err := s.createEncryptedAuth(refreshedToken)
+	statuses := make([]TokenStatus, 0, len(records))
+	for _, record := range records {
+		statuses = append(statuses, TokenStatus{
+			TeamID:      record.Key.TeamID,
+			UserID:      record.Key.UserID,
+			Status:      record.Value.Status,
+			ExpiresAt:   record.Value.ExpiresAt,
+			RefreshedAt: record.Value.RefreshedAt,
+		})
+	}
+
+	if limit <= 0 || len(records) < limit {
+		return statuses, nil, nil
+	}
+
+	last := records[len(records)-1]
+	return statuses, &core.AuthCursor{
+		ExpiresAt: last.Value.ExpiresAt,
+		Key:       last.Key,
+	}, nil
+}
+
+// Reencrypt rewrites stored tokens that were encrypted with a retired key.
+// Each token is re-read under its refresh lock so that a concurrent refresh
+// is never overwritten with stale values.
+func (s *oauthService[T]) Reencrypt(ctx context.Context) (int, error) {
+	records, err := s.lister.List(ctx, core.AuthFilter{})
+	if err != nil {
+		s.logger.Error(ctx, "Failed to list OAuth tokens for re-encryption", service.Fields{
+			"error": err.Error(),
+		})
+		return 0, err
+	}
+
+	reencrypted := 0
+	for _, record := range records {
+		if ctx.Err() != nil {
+			return reencrypted, ctx.Err()
+		}
+
+		if !s.cipher.NeedsReencryption(record.Value.AccessToken) &&
+			!s.cipher.NeedsReencryption(record.Value.RefreshToken) {
+			continue
+		}
+
+		if err := s.reencrypt(ctx, record.Key); err != nil {
+			s.logger.Warn(ctx, "Failed to re-encrypt OAuth token", service.Fields{
+				"teamID": record.Key.TeamID,
+				"userID": record.Key.UserID,
+				"error":  err.Error(),
+			})
+			continue
+		}
+
+		reencrypted++
+	}
+
+	s.logger.Info(ctx, "OAuth tokens re-encrypted", service.Fields{
+		"found":       len(records),
+		"reencrypted": reencrypted,
+	})
+	return reencrypted, nil
+}
+
+func (s *oauthService[T]) reencrypt(ctx context.Context, key core.AuthCompositeKey) error {
+	unlock, err := s.locker.Lock(ctx, fmt.Sprintf(refreshLockKey, key.TeamID, key.UserID), refreshLockTTL)
+	if err != nil {
+		return err
+	}
+
+	defer s.releaseLock(ctx, key, unlock)
+
+	storedAuth, err := s.storageService.Find(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	if storedAuth.AccessToken == "" {
+		return nil
+	}
+
+	decryptedAuth, err := s.createDecryptedAuth(storedAuth)
+	if err != nil {
+		return err
+	}
+
+	updatedAuth, err := s.createEncryptedAuth(decryptedAuth)
 	if err != nil {
-		s.logger.Error(ctx, "Failed to encrypt refreshed OAuth token", service.Fields{
+		return err
+	}
+
+	_, err = s.storageService.Update(ctx, key, updatedAuth)
+	s.invalidateCache(ctx, key)
+	return err
+}
+
+func (s *oauthService[T]) Revoke(ctx context.Context, teamID, userID string) error {
+	key := core.AuthCompositeKey{
+		TeamID: teamID,
+		UserID: userID,
+	}
+
+	storedAuth, err := s.storageService.Find(ctx, key)
+	if err != nil && !errors.Is(err, pg.ErrNoRowsAffected) {
+		s.logger.Error(ctx, "Error finding OAuth token to revoke", service.Fields{
 			"error":  err.Error(),
 			"teamID": teamID,
 			"userID": userID,
 		})
-		return component.Authentication{}, ErrTokenMissing
+		return err
 	}
 
-	if _, err = s.storageService.Update(ctx, key, updatedAuth); err != nil {
-		s.logger.Error(ctx, "Failed to update OAuth token in storage", service.Fields{
+	return s.revoke(ctx, key, storedAuth)
+}
+
+func (s *oauthService[T]) RevokeTeam(ctx context.Context, teamID string) (int, error) {
+	records, err := s.lister.List(ctx, core.AuthFilter{TeamID: teamID})
+	if err != nil {
+		s.logger.Error(ctx, "Failed to list team OAuth tokens", service.Fields{
 			"error":  err.Error(),
 			"teamID": teamID,
-			"userID": userID,
 		})
-		return component.Authentication{}, ErrTokenMissing
+		return 0, err
 	}
 
-	s.logger.Info(ctx, "Successfully refreshed and updated OAuth token", service.Fields{
-		"teamID": teamID,
-		"userID": userID,
-	})
-	return refreshedToken, nil
+	revoked := 0
+	for _, record := range records {
+		if err := s.revoke(ctx, record.Key, record.Value); err != nil {
+			return revoked, err
+		}
+
+		revoked++
+	}
+
+	return revoked, nil
+}
+
+// revoke asks Miro to invalidate the access token and deletes the stored
+// token. Revocation at Miro is best effort: the token is deleted locally
+// even when Miro cannot be reached or already considers it invalid.
+func (s *oauthService[T]) revoke(ctx context.Context, key core.AuthCompositeKey, storedAuth component.Authentication) error {
+	fields := service.Fields{
+		"teamID": key.TeamID,
+		"userID": key.UserID,
+	}
+
+	if storedAuth.AccessToken != "" {
+		if accessToken, err := s.cipher.Decrypt(storedAuth.AccessToken); err != nil {
+			fields["error"] = err.Error()
+			s.logger.Warn(ctx, "Failed to decrypt OAuth token for revocation", fields)
+		} else if err := s.oauthClient.Revoke(ctx, accessToken); err != nil {
+			fields["error"] = err.Error()
+			s.logger.Warn(ctx, "Failed to revoke OAuth token with Miro", fields)
+		}
+	}
+
+	err := s.storageService.Delete(ctx, key)
+	s.invalidateCache(ctx, key)
+	if err != nil && !errors.Is(err, pg.ErrNoRowsAffected) {
+		fields["error"] = err.Error()
+		s.logger.Error(ctx, "Failed to delete OAuth token", fields)
+		return err
+	}
+
+	delete(fields, "error")
+	s.logger.Info(ctx, "OAuth token revoked", fields)
+	return nil
+}
+
+// isRefreshRejected reports whether the authorization server refused the
+// refresh token itself rather than failing transiently. Only invalid_grant
+// means the grant is dead: invalid_client and unauthorized_client point at
+// our own configuration, and invalid_request at a malformed request.
+func isRefreshRejected(err error) bool {
+	var failed *oauth.RequestFailedError
+	if !errors.As(err, &failed) {
+		return false
+	}
+
+	return failed.Code() == errorInvalidGrant
+}
+
+// isClientMisconfigured reports whether the authorization server refused our
+// client credentials, which no stored token can recover from.
+func isClientMisconfigured(err error) bool {
+	var failed *oauth.RequestFailedError
+	if !errors.As(err, &failed) {
+		return false
+	}
+
+	return failed.Code() == errorInvalidClient || failed.Code() == errorUnauthorizedClient
 }
@@ -0,0 +1,137 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package oauth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/service"
+)
+
+// AuthorizationState is issued when an installation starts and consumed when
+// Miro redirects back with an authorization code.
+type AuthorizationState struct {
+	State     string `json:"-"`
+	Challenge string `json:"-"`
+	Verifier  string `json:"verifier,omitempty"`
+	Language  string `json:"language,omitempty"`
+}
+
+type StateService interface {
+	Issue(ctx context.Context, language string, pkce bool) (AuthorizationState, error)
+	Consume(ctx context.Context, state string) (AuthorizationState, error)
+}
+
+type stateService struct {
+	secret []byte
+	cache  service.Cache
+	logger service.Logger
+}
+
+func NewStateService(secret []byte, cache service.Cache, logger service.Logger) StateService {
+	return &stateService{
+		secret: secret,
+		cache:  cache,
+		logger: logger,
+	}
+}
+
+func (s *stateService) sign(nonce string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(nonce))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (s *stateService) buildCacheKey(nonce string) string {
+	return "oauth_state:" + nonce
+}
+
+func (s *stateService) Issue(ctx context.Context, language string, pkce bool) (AuthorizationState, error) {
+	nonce, err := randomString(stateNonceSize)
+	if err != nil {
+		return AuthorizationState{}, err
+	}
+
+	state := AuthorizationState{
+		State:    nonce + "." + s.sign(nonce),
+		Language: language,
+	}
+
+	if pkce {
+		if state.Verifier, err = randomString(pkceVerifierSize); err != nil {
+			return AuthorizationState{}, err
+		}
+
+		challenge := sha256.Sum256([]byte(state.Verifier))
+		state.Challenge = base64.RawURLEncoding.EncodeToString(challenge[:])
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return AuthorizationState{}, err
+	}
+
+	if err := s.cache.Set(ctx, s.buildCacheKey(nonce), data, stateLifetime); err != nil {
+		s.logger.Error(ctx, "Failed to store authorization state", service.Fields{"error": err.Error()})
+		return AuthorizationState{}, err
+	}
+
+	return state, nil
+}
+
+// Consume verifies the state signature and takes it from the cache in a
+// single step, so every state can be redeemed only once even by concurrent
+// callbacks.
+func (s *stateService) Consume(ctx context.Context, state string) (AuthorizationState, error) {
+	nonce, signature, ok := strings.Cut(state, ".")
+	if !ok || nonce == "" || !hmac.Equal([]byte(signature), []byte(s.sign(nonce))) {
+		return AuthorizationState{}, ErrInvalidState
+	}
+
+	data, err := s.cache.GetDel(ctx, s.buildCacheKey(nonce))
+	if err != nil {
+		return AuthorizationState{}, err
+	}
+
+	if data == nil {
+		return AuthorizationState{}, ErrStateNotFound
+	}
+
+	var result AuthorizationState
+	if err := json.Unmarshal(data, &result); err != nil {
+		return AuthorizationState{}, err
+	}
+
+	result.State = state
+	return result, nil
+}
+
+func randomString(size int) (string, error) {
+	buf := make([]byte, size)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
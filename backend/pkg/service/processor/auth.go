@@ -18,6 +18,9 @@
 package processor
 
 import (
+	"strconv"
+	"strings"
+
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/core"
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/core/component"
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/service"
@@ -25,18 +28,20 @@ import (
 )
 
 const (
-	authSelectQuery = `SELECT token_type, access_token, refresh_token, expires_at, scope
+	authSelectQuery = `SELECT token_type, access_token, refresh_token, expires_at, scope, status, refreshed_at, refresh_failures
 FROM authentications
 WHERE team_id = $1 AND user_id = $2;`
 
-	authInsertQuery = `INSERT INTO authentications (team_id, user_id, token_type, access_token, refresh_token, expires_at, scope)
-VALUES ($1, $2, $3, $4, $5, $6, $7)
+	authInsertQuery = `INSERT INTO authentications (team_id, user_id, token_type, access_token, refresh_token, expires_at, scope, status)
+VALUES ($1, $2, $3, $4, $5, $6, $7, 'active')
 ON CONFLICT (team_id, user_id) DO UPDATE
 SET token_type = EXCLUDED.token_type,
     access_token = EXCLUDED.access_token,
     refresh_token = EXCLUDED.refresh_token,
     expires_at = EXCLUDED.expires_at,
     scope = EXCLUDED.scope,
+    status = EXCLUDED.status,
+    refresh_failures = 0,
     updated_at = CURRENT_TIMESTAMP;`
 
 	authUpdateQuery = `UPDATE authentications
@@ -45,11 +50,17 @@ SET token_type = $3,
     refresh_token = $5,
     expires_at = $6,
     scope = $7,
+    status = $8,
+    refreshed_at = $9,
+    refresh_failures = $10,
     updated_at = CURRENT_TIMESTAMP
 WHERE team_id = $1 AND user_id = $2;`
 
 	authDeleteQuery = `DELETE FROM authentications
 WHERE team_id = $1 AND user_id = $2;`
+
+	authListQuery = `SELECT team_id, user_id, token_type, access_token, refresh_token, expires_at, scope, status, refreshed_at, refresh_failures
+FROM authentications`
 )
 
 type authenticationProcessor struct{}
@@ -63,6 +74,9 @@ func authenticationScanner(row pgx.Row) (*component.Authentication, error) {
 		&result.RefreshToken,
 		&result.ExpiresAt,
 		&result.Scope,
+		&result.Status,
+		&result.RefreshedAt,
+		&result.RefreshFailures,
 	); err != nil {
 		return nil, err
 	}
@@ -74,6 +88,10 @@ func NewAuthenticationProcessor() service.StorageProcessor[core.AuthCompositeKey
 	return &authenticationProcessor{}
 }
 
+func NewAuthenticationListProcessor() service.ListProcessor[core.AuthCompositeKey, component.Authentication, core.AuthFilter, pgx.Rows] {
+	return &authenticationProcessor{}
+}
+
 func (s authenticationProcessor) TableName() string {
 	return "authentications"
 }
@@ -102,6 +120,11 @@ func (s authenticationProcessor) BuildInsertQuery(id core.AuthCompositeKey, auth
 }
 
 func (s authenticationProcessor) BuildUpdateQuery(id core.AuthCompositeKey, authentication component.Authentication) (string, []any) {
+	status := authentication.Status
+	if status == "" {
+		status = component.AuthenticationStatusActive
+	}
+
 	return authUpdateQuery, []any{
 		id.TeamID,
 		id.UserID,
@@ -110,9 +133,79 @@ func (s authenticationProcessor) BuildUpdateQuery(id core.AuthCompositeKey, auth
 		authentication.RefreshToken,
 		authentication.ExpiresAt,
 		authentication.Scope,
+		status,
+		authentication.RefreshedAt,
+		authentication.RefreshFailures,
 	}
 }
 
 func (s authenticationProcessor) BuildDeleteQuery(id core.AuthCompositeKey) (string, []any) {
 	return authDeleteQuery, []any{id.TeamID, id.UserID}
 }
+
+func (s authenticationProcessor) BuildListQuery(filter core.AuthFilter) (string, []any, func(rows pgx.Rows) (core.AuthCompositeKey, component.Authentication, error)) {
+	var (
+		conditions []string
+		args       []any
+	)
+
+	if filter.TeamID != "" {
+		args = append(args, filter.TeamID)
+		conditions = append(conditions, "team_id = $"+strconv.Itoa(len(args)))
+	}
+
+	if filter.ExpiresBefore > 0 {
+		args = append(args, filter.ExpiresBefore)
+		conditions = append(conditions, "expires_at < $"+strconv.Itoa(len(args)))
+	}
+
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, "status = $"+strconv.Itoa(len(args)))
+	}
+
+	if filter.After != nil {
+		args = append(args, filter.After.ExpiresAt, filter.After.Key.TeamID, filter.After.Key.UserID)
+		conditions = append(conditions, "(expires_at, team_id, user_id) > ($"+
+			strconv.Itoa(len(args)-2)+", $"+strconv.Itoa(len(args)-1)+", $"+strconv.Itoa(len(args))+")")
+	}
+
+	var query strings.Builder
+	query.WriteString(authListQuery)
+	if len(conditions) > 0 {
+		query.WriteString("\nWHERE ")
+		query.WriteString(strings.Join(conditions, " AND "))
+	}
+
+	query.WriteString("\nORDER BY expires_at, team_id, user_id")
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query.WriteString("\nLIMIT $" + strconv.Itoa(len(args)))
+	}
+
+	query.WriteString(";")
+
+	return query.String(), args, func(rows pgx.Rows) (core.AuthCompositeKey, component.Authentication, error) {
+		var (
+			key    core.AuthCompositeKey
+			result component.Authentication
+		)
+
+		if err := rows.Scan(
+			&key.TeamID,
+			&key.UserID,
+			&result.TokenType,
+			&result.AccessToken,
+			&result.RefreshToken,
+			&result.ExpiresAt,
+			&result.Scope,
+			&result.Status,
+			&result.RefreshedAt,
+			&result.RefreshFailures,
+		); err != nil {
+			return key, result, err
+		}
+
+		return key, result, nil
+	}
+}
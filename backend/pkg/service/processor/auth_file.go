@@ -0,0 +1,140 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package processor
+
+import (
+	"sort"
+
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/core"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/core/component"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/service"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/storage/file"
+)
+
+type authenticationFileProcessor struct{}
+
+func NewAuthenticationFileProcessor() file.Processor[core.AuthCompositeKey, component.Authentication] {
+	return &authenticationFileProcessor{}
+}
+
+func NewAuthenticationFileListProcessor() file.ListProcessor[core.AuthCompositeKey, component.Authentication, core.AuthFilter] {
+	return &authenticationFileProcessor{}
+}
+
+func (s authenticationFileProcessor) TableName() string {
+	return "authentications"
+}
+
+func (s authenticationFileProcessor) key(id core.AuthCompositeKey) string {
+	return file.Key(id.TeamID, id.UserID)
+}
+
+func (s authenticationFileProcessor) Find(tx *file.Tx, id core.AuthCompositeKey) (component.Authentication, error) {
+	var result component.Authentication
+	row, ok := tx.Get(s.TableName(), s.key(id))
+	if !ok {
+		return result, service.ErrNoRowsAffected
+	}
+
+	err := row.Decode(&result)
+	return result, err
+}
+
+// Insert replaces the token and clears its failure state, keeping the time
+// of the last refresh like the Postgres upsert does.
+func (s authenticationFileProcessor) Insert(tx *file.Tx, id core.AuthCompositeKey, authentication component.Authentication) error {
+	existing, err := s.Find(tx, id)
+	if err == nil {
+		authentication.RefreshedAt = existing.RefreshedAt
+	} else {
+		authentication.RefreshedAt = nil
+	}
+
+	authentication.Status = component.AuthenticationStatusActive
+	authentication.RefreshFailures = 0
+
+	return tx.Put(s.TableName(), s.key(id), authentication)
+}
+
+func (s authenticationFileProcessor) Update(tx *file.Tx, id core.AuthCompositeKey, authentication component.Authentication) error {
+	if _, ok := tx.Get(s.TableName(), s.key(id)); !ok {
+		return service.ErrNoRowsAffected
+	}
+
+	if authentication.Status == "" {
+		authentication.Status = component.AuthenticationStatusActive
+	}
+
+	return tx.Put(s.TableName(), s.key(id), authentication)
+}
+
+func (s authenticationFileProcessor) Delete(tx *file.Tx, id core.AuthCompositeKey) error {
+	if !tx.Delete(s.TableName(), s.key(id)) {
+		return service.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+func (s authenticationFileProcessor) List(tx *file.Tx, filter core.AuthFilter) ([]service.Record[core.AuthCompositeKey, component.Authentication], error) {
+	var records []service.Record[core.AuthCompositeKey, component.Authentication]
+	for _, key := range tx.Keys(s.TableName()) {
+		parts, err := file.SplitKey(key)
+		if err != nil || len(parts) != 2 {
+			return nil, file.ErrInvalidKey
+		}
+
+		id := core.AuthCompositeKey{TeamID: parts[0], UserID: parts[1]}
+		if filter.TeamID != "" && id.TeamID != filter.TeamID {
+			continue
+		}
+
+		authentication, err := s.Find(tx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if filter.ExpiresBefore > 0 && authentication.ExpiresAt >= filter.ExpiresBefore {
+			continue
+		}
+
+		if filter.Status != "" && string(authentication.Status) != filter.Status {
+			continue
+		}
+
+		if filter.After != nil && !filter.After.Before(authentication.ExpiresAt, id) {
+			continue
+		}
+
+		records = append(records, service.Record[core.AuthCompositeKey, component.Authentication]{
+			Key:   id,
+			Value: authentication,
+		})
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		cursor := core.AuthCursor{ExpiresAt: records[i].Value.ExpiresAt, Key: records[i].Key}
+		return cursor.Before(records[j].Value.ExpiresAt, records[j].Key)
+	})
+
+	if filter.Limit > 0 && len(records) > filter.Limit {
+		records = records[:filter.Limit]
+	}
+
+	return records, nil
+}
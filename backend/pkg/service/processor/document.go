@@ -0,0 +1,78 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package processor
+
+import (
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/core"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/core/component"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/service"
+	pgx "github.com/jackc/pgx/v5"
+)
+
+const (
+	documentKeySelectQuery = `SELECT key, updated_at
+FROM document_keys
+WHERE board_id = $1 AND file_id = $2;`
+
+	documentKeyInsertQuery = `INSERT INTO document_keys (board_id, file_id, key)
+VALUES ($1, $2, $3)
+ON CONFLICT (board_id, file_id) DO UPDATE
+SET key = EXCLUDED.key,
+    updated_at = CURRENT_TIMESTAMP;`
+
+	documentKeyUpdateQuery = `UPDATE document_keys
+SET key = $3,
+    updated_at = CURRENT_TIMESTAMP
+WHERE board_id = $1 AND file_id = $2;`
+
+	documentKeyDeleteQuery = `DELETE FROM document_keys
+WHERE board_id = $1 AND file_id = $2;`
+)
+
+type documentKeyProcessor struct{}
+
+func NewDocumentKeyProcessor() service.StorageProcessor[core.DocumentKeyCompositeKey, component.DocumentKey, pgx.Row] {
+	return &documentKeyProcessor{}
+}
+
+func (s documentKeyProcessor) TableName() string {
+	return "document_keys"
+}
+
+func (s documentKeyProcessor) BuildSelectQuery(id core.DocumentKeyCompositeKey) (string, []any, func(row pgx.Row) (component.DocumentKey, error)) {
+	return documentKeySelectQuery, []any{id.BoardID, id.FileID}, func(row pgx.Row) (component.DocumentKey, error) {
+		var result component.DocumentKey
+		if err := row.Scan(&result.Key, &result.UpdatedAt); err != nil {
+			return component.DocumentKey{}, err
+		}
+
+		return result, nil
+	}
+}
+
+func (s documentKeyProcessor) BuildInsertQuery(id core.DocumentKeyCompositeKey, key component.DocumentKey) (string, []any) {
+	return documentKeyInsertQuery, []any{id.BoardID, id.FileID, key.Key}
+}
+
+func (s documentKeyProcessor) BuildUpdateQuery(id core.DocumentKeyCompositeKey, key component.DocumentKey) (string, []any) {
+	return documentKeyUpdateQuery, []any{id.BoardID, id.FileID, key.Key}
+}
+
+func (s documentKeyProcessor) BuildDeleteQuery(id core.DocumentKeyCompositeKey) (string, []any) {
+	return documentKeyDeleteQuery, []any{id.BoardID, id.FileID}
+}
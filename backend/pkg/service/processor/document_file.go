@@ -0,0 +1,76 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package processor
+
+import (
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/core"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/core/component"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/service"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/storage/file"
+)
+
+type documentKeyFileProcessor struct{}
+
+func NewDocumentKeyFileProcessor() file.Processor[core.DocumentKeyCompositeKey, component.DocumentKey] {
+	return &documentKeyFileProcessor{}
+}
+
+func (s documentKeyFileProcessor) TableName() string {
+	return "document_keys"
+}
+
+func (s documentKeyFileProcessor) key(id core.DocumentKeyCompositeKey) string {
+	return file.Key(id.BoardID, id.FileID)
+}
+
+func (s documentKeyFileProcessor) Find(tx *file.Tx, id core.DocumentKeyCompositeKey) (component.DocumentKey, error) {
+	row, ok := tx.Get(s.TableName(), s.key(id))
+	if !ok {
+		return component.DocumentKey{}, service.ErrNoRowsAffected
+	}
+
+	var key string
+	if err := row.Decode(&key); err != nil {
+		return component.DocumentKey{}, err
+	}
+
+	return component.DocumentKey{
+		Key:       key,
+		UpdatedAt: row.UpdatedAt,
+	}, nil
+}
+
+func (s documentKeyFileProcessor) Insert(tx *file.Tx, id core.DocumentKeyCompositeKey, key component.DocumentKey) error {
+	return tx.Put(s.TableName(), s.key(id), key.Key)
+}
+
+func (s documentKeyFileProcessor) Update(tx *file.Tx, id core.DocumentKeyCompositeKey, key component.DocumentKey) error {
+	if _, ok := tx.Get(s.TableName(), s.key(id)); !ok {
+		return service.ErrNoRowsAffected
+	}
+
+	return tx.Put(s.TableName(), s.key(id), key.Key)
+}
+
+func (s documentKeyFileProcessor) Delete(tx *file.Tx, id core.DocumentKeyCompositeKey) error {
+	if !tx.Delete(s.TableName(), s.key(id)) {
+		return service.ErrNoRowsAffected
+	}
+
+	return nil
+}
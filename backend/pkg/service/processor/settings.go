@@ -27,7 +27,7 @@ import (
 )
 
 const (
-	settingsSelectQuery = `SELECT s.address, s.header, s.secret, s.demo_detached,
+	settingsSelectQuery = `SELECT s.address, s.internal_address, s.header, s.secret, s.secondary_secret, s.secondary_expires_at, s.tls, s.jwt_algorithm, s.upload_mode, s.demo_detached,
 	d.enabled, d.started
 	FROM settings s
 	LEFT JOIN demos d ON s.team_id = d.team_id
@@ -38,29 +38,47 @@ SET address = $3,
     header = $4,
     secret = $5,
     demo_detached = $6,
+    internal_address = $7,
+    tls = $8,
+    secondary_secret = $9,
+    secondary_expires_at = $10,
+    jwt_algorithm = $11,
+    upload_mode = $12,
     updated_at = CURRENT_TIMESTAMP
 WHERE team_id = $1 AND board_id = $2;`
 
 	settingsDeleteQuery = `DELETE FROM settings
 WHERE team_id = $1 AND board_id = $2;`
+
+	settingsListQuery = `SELECT s.address, s.internal_address, s.header, s.secret, s.secondary_secret, s.secondary_expires_at, s.tls, s.jwt_algorithm, s.upload_mode, s.demo_detached,
+	d.enabled, d.started, s.team_id, s.board_id
+	FROM settings s
+	LEFT JOIN demos d ON s.team_id = d.team_id
+	WHERE $1 = '' OR s.team_id = $1;`
 )
 
 type settingsProcessor struct{}
 
-func settingsScanner(row pgx.Row) (*component.Settings, error) {
+func settingsScanner(row pgx.Row, extra ...any) (*component.Settings, error) {
 	result := &component.Settings{}
 	var enabled *bool
 	var started *time.Time
 	var demoDetached bool
 
-	if err := row.Scan(
+	if err := row.Scan(append([]any{
 		&result.Address,
+		&result.InternalAddress,
 		&result.Header,
 		&result.Secret,
+		&result.SecondarySecret,
+		&result.SecondaryExpiresAt,
+		&result.TLS,
+		&result.Algorithm,
+		&result.UploadMode,
 		&demoDetached,
 		&enabled,
 		&started,
-	); err != nil {
+	}, extra...)...); err != nil {
 		return nil, err
 	}
 
@@ -79,6 +97,10 @@ func NewSettingsProcessor() service.StorageProcessor[core.SettingsCompositeKey,
 	return &settingsProcessor{}
 }
 
+func NewSettingsListProcessor() service.ListProcessor[core.SettingsCompositeKey, component.Settings, core.SettingsFilter, pgx.Rows] {
+	return &settingsProcessor{}
+}
+
 func (s settingsProcessor) TableName() string {
 	return "settings"
 }
@@ -107,13 +129,19 @@ func (s settingsProcessor) BuildInsertQuery(id core.SettingsCompositeKey, settin
 
 		return `
             WITH settings_update AS (
-                INSERT INTO settings (team_id, board_id, address, header, secret, demo_detached)
-                VALUES ($1, $2, $3, $4, $5, $6)
+                INSERT INTO settings (team_id, board_id, address, header, secret, demo_detached, internal_address, tls, secondary_secret, secondary_expires_at, jwt_algorithm, upload_mode)
+                VALUES ($1, $2, $3, $4, $5, $6, $9, $10, $11, $12, $13, $14)
                 ON CONFLICT (team_id, board_id) DO UPDATE
                 SET address = EXCLUDED.address,
                     header = EXCLUDED.header,
                     secret = EXCLUDED.secret,
                     demo_detached = EXCLUDED.demo_detached,
+                    internal_address = EXCLUDED.internal_address,
+                    tls = EXCLUDED.tls,
+                    secondary_secret = EXCLUDED.secondary_secret,
+                    secondary_expires_at = EXCLUDED.secondary_expires_at,
+                    jwt_algorithm = EXCLUDED.jwt_algorithm,
+                    upload_mode = EXCLUDED.upload_mode,
                     updated_at = CURRENT_TIMESTAMP
                 RETURNING team_id
             )
@@ -130,17 +158,29 @@ func (s settingsProcessor) BuildInsertQuery(id core.SettingsCompositeKey, settin
 				settings.DemoDetached,
 				settings.Demo.Enabled,
 				started,
+				settings.InternalAddress,
+				settings.TLS,
+				settings.SecondarySecret,
+				settings.SecondaryExpiresAt,
+				settings.Algorithm,
+				settings.UploadMode,
 			}
 	}
 
 	return `
-        INSERT INTO settings (team_id, board_id, address, header, secret, demo_detached)
-        VALUES ($1, $2, $3, $4, $5, $6)
+        INSERT INTO settings (team_id, board_id, address, header, secret, demo_detached, internal_address, tls, secondary_secret, secondary_expires_at, jwt_algorithm, upload_mode)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
         ON CONFLICT (team_id, board_id) DO UPDATE
         SET address = EXCLUDED.address,
             header = EXCLUDED.header,
             secret = EXCLUDED.secret,
             demo_detached = EXCLUDED.demo_detached,
+            internal_address = EXCLUDED.internal_address,
+            tls = EXCLUDED.tls,
+            secondary_secret = EXCLUDED.secondary_secret,
+            secondary_expires_at = EXCLUDED.secondary_expires_at,
+            jwt_algorithm = EXCLUDED.jwt_algorithm,
+            upload_mode = EXCLUDED.upload_mode,
             updated_at = CURRENT_TIMESTAMP
         RETURNING team_id
     `, []any{
@@ -150,6 +190,12 @@ func (s settingsProcessor) BuildInsertQuery(id core.SettingsCompositeKey, settin
 			settings.Header,
 			settings.Secret,
 			settings.DemoDetached,
+			settings.InternalAddress,
+			settings.TLS,
+			settings.SecondarySecret,
+			settings.SecondaryExpiresAt,
+			settings.Algorithm,
+			settings.UploadMode,
 		}
 }
 
@@ -161,9 +207,31 @@ func (s settingsProcessor) BuildUpdateQuery(id core.SettingsCompositeKey, settin
 		settings.Header,
 		settings.Secret,
 		settings.DemoDetached,
+		settings.InternalAddress,
+		settings.TLS,
+		settings.SecondarySecret,
+		settings.SecondaryExpiresAt,
+		settings.Algorithm,
+		settings.UploadMode,
 	}
 }
 
 func (s settingsProcessor) BuildDeleteQuery(id core.SettingsCompositeKey) (string, []any) {
 	return settingsDeleteQuery, []any{id.TeamID, id.BoardID}
 }
+
+func (s settingsProcessor) BuildListQuery(filter core.SettingsFilter) (string, []any, func(pgx.Rows) (core.SettingsCompositeKey, component.Settings, error)) {
+	return settingsListQuery, []any{filter.TeamID}, func(rows pgx.Rows) (core.SettingsCompositeKey, component.Settings, error) {
+		var key core.SettingsCompositeKey
+		settings, err := settingsScanner(rows, &key.TeamID, &key.BoardID)
+		if err != nil {
+			return key, component.Settings{}, err
+		}
+
+		if settings.Demo != (component.Demo{}) {
+			settings.Demo.TeamID = key.TeamID
+		}
+
+		return key, *settings, nil
+	}
+}
@@ -0,0 +1,156 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package processor
+
+import (
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/core"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/core/component"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/service"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/storage/file"
+)
+
+const demosTableName = "demos"
+
+// settingsFileProcessor keeps demos in a table of their own, keyed by team,
+// so that they outlive the settings of a board like their Postgres rows do.
+type settingsFileProcessor struct{}
+
+func NewSettingsFileProcessor() file.Processor[core.SettingsCompositeKey, component.Settings] {
+	return &settingsFileProcessor{}
+}
+
+func NewSettingsFileListProcessor() file.ListProcessor[core.SettingsCompositeKey, component.Settings, core.SettingsFilter] {
+	return &settingsFileProcessor{}
+}
+
+func (s settingsFileProcessor) TableName() string {
+	return "settings"
+}
+
+func (s settingsFileProcessor) key(id core.SettingsCompositeKey) string {
+	return file.Key(id.TeamID, id.BoardID)
+}
+
+func (s settingsFileProcessor) Find(tx *file.Tx, id core.SettingsCompositeKey) (component.Settings, error) {
+	var result component.Settings
+	row, ok := tx.Get(s.TableName(), s.key(id))
+	if !ok {
+		return result, service.ErrNoRowsAffected
+	}
+
+	if err := row.Decode(&result); err != nil {
+		return component.Settings{}, err
+	}
+
+	result.Demo = component.Demo{}
+	if result.DemoDetached {
+		return result, nil
+	}
+
+	if demoRow, ok := tx.Get(demosTableName, file.Key(id.TeamID)); ok {
+		var demo component.Demo
+		if err := demoRow.Decode(&demo); err != nil {
+			return component.Settings{}, err
+		}
+
+		if demo.Started != nil {
+			result.Demo = component.Demo{
+				TeamID:  id.TeamID,
+				Enabled: demo.Enabled,
+				Started: demo.Started,
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// Insert stores the settings of a board and starts the team's demo unless
+// one was started before.
+func (s settingsFileProcessor) Insert(tx *file.Tx, id core.SettingsCompositeKey, settings component.Settings) error {
+	demo := settings.Demo
+	settings.Demo = component.Demo{}
+
+	if err := tx.Put(s.TableName(), s.key(id), settings); err != nil {
+		return err
+	}
+
+	if !demo.Enabled {
+		return nil
+	}
+
+	if _, ok := tx.Get(demosTableName, file.Key(id.TeamID)); ok {
+		return nil
+	}
+
+	var started *time.Time
+	if demo.Started != nil && !demo.Started.IsZero() {
+		started = demo.Started
+	}
+
+	return tx.Put(demosTableName, file.Key(id.TeamID), component.Demo{
+		Enabled: true,
+		Started: started,
+	})
+}
+
+func (s settingsFileProcessor) Update(tx *file.Tx, id core.SettingsCompositeKey, settings component.Settings) error {
+	if _, ok := tx.Get(s.TableName(), s.key(id)); !ok {
+		return service.ErrNoRowsAffected
+	}
+
+	settings.Demo = component.Demo{}
+	return tx.Put(s.TableName(), s.key(id), settings)
+}
+
+func (s settingsFileProcessor) Delete(tx *file.Tx, id core.SettingsCompositeKey) error {
+	if !tx.Delete(s.TableName(), s.key(id)) {
+		return service.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+func (s settingsFileProcessor) List(tx *file.Tx, filter core.SettingsFilter) ([]service.Record[core.SettingsCompositeKey, component.Settings], error) {
+	var records []service.Record[core.SettingsCompositeKey, component.Settings]
+	for _, key := range tx.Keys(s.TableName()) {
+		parts, err := file.SplitKey(key)
+		if err != nil || len(parts) != 2 {
+			return nil, file.ErrInvalidKey
+		}
+
+		id := core.SettingsCompositeKey{TeamID: parts[0], BoardID: parts[1]}
+		if filter.TeamID != "" && id.TeamID != filter.TeamID {
+			continue
+		}
+
+		settings, err := s.Find(tx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		records = append(records, service.Record[core.SettingsCompositeKey, component.Settings]{
+			Key:   id,
+			Value: settings,
+		})
+	}
+
+	return records, nil
+}
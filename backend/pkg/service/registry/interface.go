@@ -0,0 +1,29 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package registry
+
+import "context"
+
+// KeyRegistry pins Document Server keys for documents with an open editing
+// session, so that every participant joins the same session.
+type KeyRegistry interface {
+	Find(ctx context.Context, boardID, fileID string) (string, error)
+	Pin(ctx context.Context, boardID, fileID, key string) error
+	Release(ctx context.Context, boardID, fileID string) error
+	Collect(ctx context.Context) (int64, error)
+}
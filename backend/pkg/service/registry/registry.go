@@ -0,0 +1,149 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/core"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/core/component"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/service"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/storage/pg"
+)
+
+const (
+	// keyLifetime is how long a pinned key survives without session activity.
+	keyLifetime            = 24 * time.Hour
+	defaultCacheExpiration = 5 * time.Minute
+)
+
+type keyRegistry struct {
+	cache          service.Cache
+	storageService service.Storage[core.DocumentKeyCompositeKey, component.DocumentKey]
+	sweeper        service.Sweeper
+	logger         service.Logger
+}
+
+func NewKeyRegistry(
+	cache service.Cache,
+	storageService service.Storage[core.DocumentKeyCompositeKey, component.DocumentKey],
+	sweeper service.Sweeper,
+	logger service.Logger,
+) KeyRegistry {
+	return &keyRegistry{
+		cache:          cache,
+		storageService: storageService,
+		sweeper:        sweeper,
+		logger:         logger,
+	}
+}
+
+func (r *keyRegistry) buildCacheKey(boardID, fileID string) string {
+	return fmt.Sprintf("document_key:%s:%s", boardID, fileID)
+}
+
+func (r *keyRegistry) createCompositeKey(boardID, fileID string) core.DocumentKeyCompositeKey {
+	return core.DocumentKeyCompositeKey{
+		BoardID: boardID,
+		FileID:  fileID,
+	}
+}
+
+func (r *keyRegistry) Find(ctx context.Context, boardID, fileID string) (string, error) {
+	fields := service.Fields{"board_id": boardID, "file_id": fileID}
+	cacheKey := r.buildCacheKey(boardID, fileID)
+	if cached, err := r.cache.Get(ctx, cacheKey); err != nil {
+		r.logger.Warn(ctx, "Failed to read document key from cache", service.Fields{"error": err.Error()})
+	} else if cached != nil {
+		return string(cached), nil
+	}
+
+	documentKey, err := r.storageService.Find(ctx, r.createCompositeKey(boardID, fileID))
+	if err != nil {
+		if errors.Is(err, pg.ErrNoRowsAffected) {
+			return "", nil
+		}
+
+		r.logger.Error(ctx, "Failed to retrieve document key", fields)
+		return "", err
+	}
+
+	if documentKey.Key == "" {
+		return "", nil
+	}
+
+	if time.Since(documentKey.UpdatedAt) > keyLifetime {
+		r.logger.Debug(ctx, "Pinned document key is stale", fields)
+		return "", r.Release(ctx, boardID, fileID)
+	}
+
+	if err := r.cache.Set(ctx, cacheKey, []byte(documentKey.Key), defaultCacheExpiration); err != nil {
+		r.logger.Warn(ctx, "Failed to cache document key", service.Fields{"error": err.Error()})
+	}
+
+	return documentKey.Key, nil
+}
+
+func (r *keyRegistry) Pin(ctx context.Context, boardID, fileID, key string) error {
+	if key == "" {
+		return ErrEmptyDocumentKey
+	}
+
+	r.logger.Debug(ctx, "Pinning document key", service.Fields{"board_id": boardID, "file_id": fileID})
+	if _, err := r.storageService.Insert(ctx, r.createCompositeKey(boardID, fileID), component.DocumentKey{
+		Key: key,
+	}); err != nil {
+		return err
+	}
+
+	if err := r.cache.Set(ctx, r.buildCacheKey(boardID, fileID), []byte(key), defaultCacheExpiration); err != nil {
+		r.logger.Warn(ctx, "Failed to cache document key", service.Fields{"error": err.Error()})
+	}
+
+	return nil
+}
+
+func (r *keyRegistry) Release(ctx context.Context, boardID, fileID string) error {
+	r.logger.Debug(ctx, "Releasing document key", service.Fields{"board_id": boardID, "file_id": fileID})
+	if err := r.cache.Delete(ctx, r.buildCacheKey(boardID, fileID)); err != nil {
+		r.logger.Warn(ctx, "Failed to invalidate document key cache", service.Fields{"error": err.Error()})
+	}
+
+	if err := r.storageService.Delete(ctx, r.createCompositeKey(boardID, fileID)); err != nil &&
+		!errors.Is(err, pg.ErrNoRowsAffected) {
+		return err
+	}
+
+	return nil
+}
+
+func (r *keyRegistry) Collect(ctx context.Context) (int64, error) {
+	removed, err := r.sweeper.Sweep(ctx, time.Now().Add(-keyLifetime))
+	if err != nil {
+		return 0, err
+	}
+
+	if removed > 0 {
+		r.logger.Info(ctx, "Stale document keys collected", service.Fields{"removed": removed})
+	}
+
+	return removed, nil
+}
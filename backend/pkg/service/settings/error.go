@@ -26,8 +26,18 @@ var (
 	ErrSettingsInvalidURL                  = errors.New("features.settings.form.errors.invalid_url")
 	ErrSettingsInvalidProtocol             = errors.New("features.settings.form.errors.invalid_protocol")
 	ErrSettingsTrailingSlash               = errors.New("features.settings.form.errors.trailing_slash")
+	ErrSettingsInvalidInternalURL          = errors.New("features.settings.form.errors.invalid_internal_url")
+	ErrSettingsInvalidInternalProtocol     = errors.New("features.settings.form.errors.invalid_internal_protocol")
+	ErrSettingsInternalTrailingSlash       = errors.New("features.settings.form.errors.internal_trailing_slash")
+	ErrSettingsInternalAddressTooLong      = errors.New("features.settings.form.errors.internal_address_too_long")
 	ErrSettingsHeaderTooLong               = errors.New("features.settings.form.errors.header_too_long")
 	ErrSettingsSecretTooLong               = errors.New("features.settings.form.errors.secret_too_long")
+	ErrSettingsInvalidAlgorithm            = errors.New("features.settings.form.errors.invalid_algorithm")
+	ErrSettingsInvalidUploadMode           = errors.New("features.settings.form.errors.invalid_upload_mode")
+	ErrSettingsInvalidCABundle             = errors.New("features.settings.form.errors.invalid_ca_bundle")
+	ErrSettingsInvalidClientCertificate    = errors.New("features.settings.form.errors.invalid_client_certificate")
+	ErrSettingsInvalidPinnedCertificate    = errors.New("features.settings.form.errors.invalid_pinned_certificate")
+	ErrSettingsInvalidTLS                  = errors.New("features.settings.form.errors.invalid_tls")
 	ErrSettingsRetrievalError              = errors.New("features.settings.form.errors.retrieval_error")
 	ErrSettingsBadJwtError                 = errors.New("features.settings.form.errors.bad_jwt")
 	ErrDocumentServerVersionRetrievalError = errors.New("features.settings.form.errors.document_server_version_retrieval_error")
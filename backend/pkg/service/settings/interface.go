@@ -26,4 +26,6 @@ import (
 type SettingsService interface {
 	Save(ctx context.Context, teamID, boardID string, opts ...Option) error
 	Find(ctx context.Context, teamID, boardID string) (component.Settings, error)
+	DeleteTeam(ctx context.Context, teamID string) (int, error)
+	Reencrypt(ctx context.Context) (int, error)
 }
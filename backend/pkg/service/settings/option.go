@@ -18,15 +18,24 @@
 package settings
 
 import (
+	"errors"
 	"net/url"
 	"strings"
+
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/core/component"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/crypto"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/client/docserver"
 )
 
 type SaveOptions struct {
-	Address string
-	Header  string
-	Secret  string
-	Demo    bool
+	Address         string
+	InternalAddress string
+	Header          string
+	Secret          string
+	Algorithm       string
+	UploadMode      component.UploadMode
+	TLS             component.TLS
+	Demo            bool
 }
 
 func (o *SaveOptions) Validate() error {
@@ -57,6 +66,29 @@ func (o *SaveOptions) Validate() error {
 		}
 	}
 
+	if o.InternalAddress != "" {
+		if o.Address == "" {
+			return ErrSettingsAddressRequired
+		}
+
+		u, err := url.Parse(o.InternalAddress)
+		if err != nil || u.Host == "" {
+			return ErrSettingsInvalidInternalURL
+		}
+
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return ErrSettingsInvalidInternalProtocol
+		}
+
+		if strings.HasSuffix(o.InternalAddress, "/") {
+			return ErrSettingsInternalTrailingSlash
+		}
+
+		if len(o.InternalAddress) > 255 {
+			return ErrSettingsInternalAddressTooLong
+		}
+	}
+
 	if len(o.Header) > 255 {
 		return ErrSettingsHeaderTooLong
 	}
@@ -65,9 +97,36 @@ func (o *SaveOptions) Validate() error {
 		return ErrSettingsSecretTooLong
 	}
 
+	if !crypto.Algorithm(o.Algorithm).IsValid() {
+		return ErrSettingsInvalidAlgorithm
+	}
+
+	switch o.UploadMode {
+	case "", component.UploadModeURL, component.UploadModeContent:
+	default:
+		return ErrSettingsInvalidUploadMode
+	}
+
+	if err := o.TLSConfig().Validate(); err != nil {
+		switch {
+		case errors.Is(err, docserver.ErrInvalidCABundle):
+			return ErrSettingsInvalidCABundle
+		case errors.Is(err, docserver.ErrInvalidClientCertificate):
+			return ErrSettingsInvalidClientCertificate
+		case errors.Is(err, docserver.ErrInvalidPinnedCertificate):
+			return ErrSettingsInvalidPinnedCertificate
+		default:
+			return ErrSettingsInvalidTLS
+		}
+	}
+
 	return nil
 }
 
+func (o *SaveOptions) TLSConfig() docserver.TLSConfig {
+	return docserver.NewTLSConfig(o.TLS)
+}
+
 type Option func(*SaveOptions)
 
 func WithAddress(val string) Option {
@@ -76,6 +135,12 @@ func WithAddress(val string) Option {
 	}
 }
 
+func WithInternalAddress(val string) Option {
+	return func(o *SaveOptions) {
+		o.InternalAddress = val
+	}
+}
+
 func WithHeader(val string) Option {
 	return func(o *SaveOptions) {
 		o.Header = val
@@ -88,6 +153,24 @@ func WithSecret(val string) Option {
 	}
 }
 
+func WithAlgorithm(val string) Option {
+	return func(o *SaveOptions) {
+		o.Algorithm = val
+	}
+}
+
+func WithUploadMode(val component.UploadMode) Option {
+	return func(o *SaveOptions) {
+		o.UploadMode = val
+	}
+}
+
+func WithTLS(val component.TLS) Option {
+	return func(o *SaveOptions) {
+		o.TLS = val
+	}
+}
+
 func WithDemo(val bool) Option {
 	return func(o *SaveOptions) {
 		o.Demo = val
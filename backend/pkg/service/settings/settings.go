@@ -32,11 +32,19 @@ import (
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/crypto"
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/service"
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/client/docserver"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/lock"
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/storage/pg"
 	jwt "github.com/golang-jwt/jwt/v5"
 )
 
-const defaultCacheExpiration = 5 * time.Minute
+const (
+	defaultCacheExpiration = 5 * time.Minute
+	// settingsLockKey identifies the lock held while the settings of a board
+	// are written.
+	settingsLockKey = "settings:%s:%s"
+	// settingsLockTTL outlives a save, including the Document Server check.
+	settingsLockTTL = 30 * time.Second
+)
 
 type settingsService struct {
 	config          *config.Config
@@ -45,6 +53,8 @@ type settingsService struct {
 	cipher          crypto.Cipher
 	jwtService      crypto.Signer
 	storageService  service.Storage[core.SettingsCompositeKey, component.Settings]
+	lister          service.Lister[core.SettingsCompositeKey, component.Settings, core.SettingsFilter]
+	locker          service.Locker
 	logger          service.Logger
 }
 
@@ -55,6 +65,8 @@ func NewSettingsService(
 	cipher crypto.Cipher,
 	jwtService crypto.Signer,
 	storageService service.Storage[core.SettingsCompositeKey, component.Settings],
+	lister service.Lister[core.SettingsCompositeKey, component.Settings, core.SettingsFilter],
+	locker service.Locker,
 	logger service.Logger,
 ) SettingsService {
 	return &settingsService{
@@ -64,6 +76,8 @@ func NewSettingsService(
 		cipher:          cipher,
 		jwtService:      jwtService,
 		storageService:  storageService,
+		lister:          lister,
+		locker:          locker,
 		logger:          logger,
 	}
 }
@@ -79,6 +93,18 @@ func (s *settingsService) createCompositeKey(teamID, boardID string) core.Settin
 	}
 }
 
+// lockBoard serializes writes to the settings of a board, so that a save and
+// a re-encryption never overwrite each other with stale values.
+func (s *settingsService) lockBoard(ctx context.Context, key core.SettingsCompositeKey) (service.Unlock, error) {
+	return s.locker.Lock(ctx, fmt.Sprintf(settingsLockKey, key.TeamID, key.BoardID), settingsLockTTL)
+}
+
+func (s *settingsService) unlockBoard(ctx context.Context, key core.SettingsCompositeKey, unlock service.Unlock) {
+	if err := unlock(ctx); err != nil {
+		s.logEvent(ctx, config.Warn, "Failed to release settings lock", key.TeamID, key.BoardID, err)
+	}
+}
+
 func (s *settingsService) logEvent(ctx context.Context, level config.Level, message string, teamID, boardID string, err error) {
 	fields := service.Fields{
 		"team_id":  teamID,
@@ -125,6 +151,88 @@ func (s *settingsService) decryptSecret(encryptedSecret string) (string, error)
 	return decrypted, nil
 }
 
+func (s *settingsService) encryptTLS(tls component.TLS) (component.TLS, error) {
+	var err error
+	encrypted := tls
+
+	if encrypted.CABundle, err = s.encryptSecret(tls.CABundle); err != nil {
+		return component.TLS{}, err
+	}
+
+	if encrypted.ClientCertificate, err = s.encryptSecret(tls.ClientCertificate); err != nil {
+		return component.TLS{}, err
+	}
+
+	if encrypted.ClientKey, err = s.encryptSecret(tls.ClientKey); err != nil {
+		return component.TLS{}, err
+	}
+
+	return encrypted, nil
+}
+
+func (s *settingsService) decryptTLS(tls component.TLS) (component.TLS, error) {
+	var err error
+	decrypted := tls
+
+	if decrypted.CABundle, err = s.decryptSecret(tls.CABundle); err != nil {
+		return component.TLS{}, err
+	}
+
+	if decrypted.ClientCertificate, err = s.decryptSecret(tls.ClientCertificate); err != nil {
+		return component.TLS{}, err
+	}
+
+	if decrypted.ClientKey, err = s.decryptSecret(tls.ClientKey); err != nil {
+		return component.TLS{}, err
+	}
+
+	return decrypted, nil
+}
+
+// keepClientKey reuses the stored client key when a client certificate is
+// saved without one, since the key is never sent back to the frontend.
+func (s *settingsService) keepClientKey(opts *SaveOptions, existingSettings component.Settings) error {
+	if opts.TLS.ClientKey != "" || opts.TLS.ClientCertificate == "" {
+		return nil
+	}
+
+	clientKey, err := s.decryptSecret(existingSettings.TLS.ClientKey)
+	if err != nil {
+		return err
+	}
+
+	opts.TLS.ClientKey = clientKey
+	return nil
+}
+
+func (s *settingsService) rotateSecret(secret string, existingSettings component.Settings) (string, *time.Time, error) {
+	if secret == "" {
+		return "", nil, nil
+	}
+
+	previous, err := s.decryptSecret(existingSettings.Secret)
+	if err != nil {
+		return "", nil, err
+	}
+
+	now := time.Now()
+	if previous != "" && previous != secret {
+		if s.config.Server.SecretRotationWindow <= 0 {
+			return "", nil, nil
+		}
+
+		expiresAt := now.Add(s.config.Server.SecretRotationWindow)
+		return existingSettings.Secret, &expiresAt, nil
+	}
+
+	if existingSettings.SecondarySecret != "" && existingSettings.SecondaryExpiresAt != nil &&
+		existingSettings.SecondaryExpiresAt.After(now) {
+		return existingSettings.SecondarySecret, existingSettings.SecondaryExpiresAt, nil
+	}
+
+	return "", nil, nil
+}
+
 func (s *settingsService) createDemoSettings(teamID string, existingStarted *time.Time) component.Demo {
 	demoSettings := component.Demo{
 		TeamID:  teamID,
@@ -161,6 +269,14 @@ func (s *settingsService) cacheSettings(ctx context.Context, teamID, boardID str
 	}
 }
 
+func uploadModeOrDefault(mode component.UploadMode) component.UploadMode {
+	if mode == "" {
+		return component.UploadModeURL
+	}
+
+	return mode
+}
+
 func validateDocServerVersion(version string) error {
 	parts := strings.Split(version, ".")
 	if len(parts) < 2 {
@@ -190,33 +306,56 @@ func (s *settingsService) Save(ctx context.Context, teamID, boardID string, opts
 		opt(settings)
 	}
 
-	if err := settings.Validate(); err != nil {
-		s.logEvent(ctx, config.Error, "Invalid settings options", teamID, boardID, err)
-		return err
+	compositeKey := s.createCompositeKey(teamID, boardID)
+	unlock, err := s.lockBoard(ctx, compositeKey)
+	if errors.Is(err, lock.ErrLockNotAcquired) {
+		s.logEvent(ctx, config.Error, "Timed out waiting for settings lock", teamID, boardID, err)
+		return ErrSettingsPersistenceError
+	}
+
+	if err != nil {
+		s.logEvent(ctx, config.Warn, "Saving settings without a distributed lock", teamID, boardID, err)
+	} else {
+		defer s.unlockBoard(ctx, compositeKey, unlock)
 	}
 
-	compositeKey := s.createCompositeKey(teamID, boardID)
 	existingSettings, err := s.storageService.Find(ctx, compositeKey)
 	if err != nil && !errors.Is(err, pg.ErrNoRowsAffected) {
 		s.logEvent(ctx, config.Error, "Failed to retrieve existing settings", teamID, boardID, err)
 		return ErrSettingsRetrievalError
 	}
 
+	if err := s.keepClientKey(settings, existingSettings); err != nil {
+		s.logEvent(ctx, config.Error, "Failed to decrypt stored client key", teamID, boardID, err)
+		return ErrSettingsRetrievalError
+	}
+
+	if err := settings.Validate(); err != nil {
+		s.logEvent(ctx, config.Error, "Invalid settings options", teamID, boardID, err)
+		return err
+	}
+
 	s.logEvent(ctx, config.Debug, "Validating document server", teamID, boardID, nil)
 	if settings.Address != "" && settings.Header != "" && settings.Secret != "" {
-		token, err := s.jwtService.Create(jwt.MapClaims{
+		token, err := s.jwtService.CreateWithAlgorithm(jwt.MapClaims{
 			"payload": map[string]string{
 				"c": "version",
 			},
-		}, []byte(settings.Secret))
+		}, []byte(settings.Secret), crypto.Algorithm(settings.Algorithm))
 
 		if err != nil {
 			s.logEvent(ctx, config.Error, "Failed to create JWT token", teamID, boardID, err)
 			return ErrSettingsBadJwtError
 		}
 
-		response, err := s.docServerClient.GetServerVersion(ctx, settings.Address,
-			docserver.WithHeader(settings.Header), docserver.WithToken(fmt.Sprintf("Bearer %s", token)))
+		address := settings.Address
+		if settings.InternalAddress != "" {
+			address = settings.InternalAddress
+		}
+
+		response, err := s.docServerClient.GetServerVersion(ctx, address,
+			docserver.WithHeader(settings.Header), docserver.WithToken(fmt.Sprintf("Bearer %s", token)),
+			docserver.WithTLSConfig(settings.TLSConfig()))
 		if err != nil {
 			s.logEvent(ctx, config.Error, "Failed to connect to document server", teamID, boardID, err)
 			return ErrDocumentServerVersionRetrievalError
@@ -265,9 +404,25 @@ func (s *settingsService) buildNewSettings(teamID string, opts *SaveOptions, exi
 				return newSettings, err
 			}
 
+			encTLS, err := s.encryptTLS(opts.TLS)
+			if err != nil {
+				return newSettings, err
+			}
+
+			secondarySecret, secondaryExpiresAt, err := s.rotateSecret(opts.Secret, existingSettings)
+			if err != nil {
+				return newSettings, err
+			}
+
 			newSettings.Address = opts.Address
+			newSettings.InternalAddress = opts.InternalAddress
 			newSettings.Header = opts.Header
 			newSettings.Secret = encSecret
+			newSettings.Algorithm = crypto.Algorithm(opts.Algorithm).SigningMethod().Alg()
+			newSettings.UploadMode = uploadModeOrDefault(opts.UploadMode)
+			newSettings.SecondarySecret = secondarySecret
+			newSettings.SecondaryExpiresAt = secondaryExpiresAt
+			newSettings.TLS = encTLS
 		}
 
 		return newSettings, nil
@@ -278,10 +433,26 @@ func (s *settingsService) buildNewSettings(teamID string, opts *SaveOptions, exi
 		return newSettings, err
 	}
 
+	encTLS, err := s.encryptTLS(opts.TLS)
+	if err != nil {
+		return newSettings, err
+	}
+
+	secondarySecret, secondaryExpiresAt, err := s.rotateSecret(opts.Secret, existingSettings)
+	if err != nil {
+		return newSettings, err
+	}
+
 	newSettings = component.Settings{
-		Address: opts.Address,
-		Header:  opts.Header,
-		Secret:  encSecret,
+		Address:            opts.Address,
+		InternalAddress:    opts.InternalAddress,
+		Header:             opts.Header,
+		Secret:             encSecret,
+		Algorithm:          crypto.Algorithm(opts.Algorithm).SigningMethod().Alg(),
+		UploadMode:         uploadModeOrDefault(opts.UploadMode),
+		SecondarySecret:    secondarySecret,
+		SecondaryExpiresAt: secondaryExpiresAt,
+		TLS:                encTLS,
 	}
 
 	if opts.Address != "" || opts.Header != "" || opts.Secret != "" {
@@ -345,6 +516,18 @@ func (s *settingsService) getFromCache(ctx context.Context, teamID, boardID stri
 		settings.Secret = decSecret
 	}
 
+	decSecondarySecret, err := s.decryptSecret(settings.SecondarySecret)
+	if err != nil {
+		return settings, false, err
+	}
+	settings.SecondarySecret = decSecondarySecret
+
+	decTLS, err := s.decryptTLS(settings.TLS)
+	if err != nil {
+		return settings, false, err
+	}
+	settings.TLS = decTLS
+
 	s.logEvent(ctx, config.Debug, "Settings retrieved from cache", teamID, boardID, nil)
 	return settings, true, nil
 }
@@ -382,5 +565,136 @@ func (s *settingsService) getFromStorage(ctx context.Context, teamID, boardID st
 		settings.Secret = decSecret
 	}
 
+	decSecondarySecret, err := s.decryptSecret(settings.SecondarySecret)
+	if err != nil {
+		s.logEvent(ctx, config.Error, "Failed to decrypt secondary secret", teamID, boardID, err)
+		return settings, err
+	}
+	settings.SecondarySecret = decSecondarySecret
+
+	decTLS, err := s.decryptTLS(settings.TLS)
+	if err != nil {
+		s.logEvent(ctx, config.Error, "Failed to decrypt TLS settings", teamID, boardID, err)
+		return settings, err
+	}
+	settings.TLS = decTLS
+
 	return settings, nil
 }
+
+// DeleteTeam removes every board's settings of a team together with their
+// cached copies. Demo records are kept so that a reinstall cannot restart
+// an expired trial.
+func (s *settingsService) DeleteTeam(ctx context.Context, teamID string) (int, error) {
+	records, err := s.lister.List(ctx, core.SettingsFilter{TeamID: teamID})
+	if err != nil {
+		s.logEvent(ctx, config.Error, "Failed to list team settings", teamID, "", err)
+		return 0, err
+	}
+
+	deleted := 0
+	for _, record := range records {
+		if err := s.storageService.Delete(ctx, record.Key); err != nil && !errors.Is(err, pg.ErrNoRowsAffected) {
+			s.logEvent(ctx, config.Error, "Failed to delete settings", teamID, record.Key.BoardID, err)
+			return deleted, err
+		}
+
+		s.invalidateCache(ctx, teamID, record.Key.BoardID)
+		deleted++
+	}
+
+	s.logEvent(ctx, config.Debug, "Team settings deleted", teamID, "", nil)
+	return deleted, nil
+}
+
+func (s *settingsService) needsReencryption(settings component.Settings) bool {
+	for _, secret := range []string{
+		settings.Secret,
+		settings.SecondarySecret,
+		settings.TLS.CABundle,
+		settings.TLS.ClientCertificate,
+		settings.TLS.ClientKey,
+	} {
+		if s.cipher.NeedsReencryption(secret) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *settingsService) reencryptSecret(encryptedSecret string) (string, error) {
+	secret, err := s.decryptSecret(encryptedSecret)
+	if err != nil {
+		return "", err
+	}
+
+	return s.encryptSecret(secret)
+}
+
+// Reencrypt rewrites stored settings whose secrets were encrypted with a
+// retired key. Each board is re-read under its settings lock, and settings
+// that fail to re-encrypt are skipped and retried on the next run.
+func (s *settingsService) Reencrypt(ctx context.Context) (int, error) {
+	records, err := s.lister.List(ctx, core.SettingsFilter{})
+	if err != nil {
+		s.logEvent(ctx, config.Error, "Failed to list settings for re-encryption", "", "", err)
+		return 0, err
+	}
+
+	reencrypted := 0
+	for _, record := range records {
+		if ctx.Err() != nil {
+			return reencrypted, ctx.Err()
+		}
+
+		if !s.needsReencryption(record.Value) {
+			continue
+		}
+
+		if err := s.reencrypt(ctx, record.Key); err != nil {
+			s.logEvent(ctx, config.Warn, "Failed to re-encrypt settings", record.Key.TeamID, record.Key.BoardID, err)
+			continue
+		}
+
+		reencrypted++
+	}
+
+	s.logEvent(ctx, config.Debug, fmt.Sprintf("Re-encrypted %d of %d settings", reencrypted, len(records)), "", "", nil)
+	return reencrypted, nil
+}
+
+func (s *settingsService) reencrypt(ctx context.Context, key core.SettingsCompositeKey) error {
+	unlock, err := s.lockBoard(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	defer s.unlockBoard(ctx, key, unlock)
+
+	settings, err := s.storageService.Find(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	if settings.Secret, err = s.reencryptSecret(settings.Secret); err != nil {
+		return err
+	}
+
+	if settings.SecondarySecret, err = s.reencryptSecret(settings.SecondarySecret); err != nil {
+		return err
+	}
+
+	decTLS, err := s.decryptTLS(settings.TLS)
+	if err != nil {
+		return err
+	}
+
+	if settings.TLS, err = s.encryptTLS(decTLS); err != nil {
+		return err
+	}
+
+	_, err = s.storageService.Update(ctx, key, settings)
+	s.invalidateCache(ctx, key.TeamID, key.BoardID)
+	return err
+}
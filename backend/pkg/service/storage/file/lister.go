@@ -0,0 +1,67 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package file
+
+import (
+	"context"
+
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/service"
+)
+
+type fileLister[K comparable, V any, F any] struct {
+	store     *Store
+	processor ListProcessor[K, V, F]
+	logger    service.Logger
+}
+
+func NewFileLister[K comparable, V any, F any](
+	store *Store,
+	processor ListProcessor[K, V, F],
+	logger service.Logger,
+) (service.Lister[K, V, F], error) {
+	if store == nil {
+		return nil, ErrNilStore
+	}
+
+	return &fileLister[K, V, F]{
+		store:     store,
+		processor: processor,
+		logger:    logger,
+	}, nil
+}
+
+func (l *fileLister[K, V, F]) List(ctx context.Context, filter F) ([]service.Record[K, V], error) {
+	var records []service.Record[K, V]
+	err := l.store.View(func(tx *Tx) error {
+		var err error
+		records, err = l.processor.List(tx, filter)
+		return err
+	})
+
+	if err != nil {
+		l.logger.Error(ctx, "Error listing records", service.Fields{
+			"error": err.Error(),
+		})
+		return nil, err
+	}
+
+	l.logger.Debug(ctx, "Records listed successfully", service.Fields{
+		"count": len(records),
+	})
+	return records, nil
+}
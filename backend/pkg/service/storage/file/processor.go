@@ -0,0 +1,35 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package file
+
+import "github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/service"
+
+// Processor maps a component onto rows of the file store. Implementations
+// return service.ErrNoRowsAffected when the record does not exist.
+type Processor[K comparable, V any] interface {
+	TableName() string
+	Find(tx *Tx, id K) (V, error)
+	Insert(tx *Tx, id K, value V) error
+	Update(tx *Tx, id K, value V) error
+	Delete(tx *Tx, id K) error
+}
+
+// ListProcessor returns every record of a table matching the filter.
+type ListProcessor[K comparable, V any, F any] interface {
+	List(tx *Tx, filter F) ([]service.Record[K, V], error)
+}
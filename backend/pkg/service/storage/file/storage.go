@@ -0,0 +1,109 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package file
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/service"
+)
+
+type fileStorage[ID comparable, T any] struct {
+	store     *Store
+	processor Processor[ID, T]
+	logger    service.Logger
+}
+
+func NewFileStorage[ID comparable, T any](
+	store *Store,
+	processor Processor[ID, T],
+	logger service.Logger,
+) (service.Storage[ID, T], error) {
+	if store == nil {
+		return nil, ErrNilStore
+	}
+
+	return &fileStorage[ID, T]{
+		store:     store,
+		processor: processor,
+		logger:    logger,
+	}, nil
+}
+
+func (s *fileStorage[ID, T]) logResult(ctx context.Context, operation string, id ID, err error) {
+	if err == nil {
+		s.logger.Debug(ctx, "Record "+operation+" successfully", service.Fields{"id": id})
+		return
+	}
+
+	if errors.Is(err, service.ErrNoRowsAffected) {
+		s.logger.Debug(ctx, "No record found", service.Fields{"id": id})
+		return
+	}
+
+	s.logger.Error(ctx, "Error processing record", service.Fields{
+		"id":        id,
+		"operation": operation,
+		"error":     err.Error(),
+	})
+}
+
+func (s *fileStorage[ID, T]) Find(ctx context.Context, id ID) (T, error) {
+	var result T
+
+	s.logger.Debug(ctx, "Finding record by ID", service.Fields{"id": id})
+	err := s.store.View(func(tx *Tx) error {
+		var err error
+		result, err = s.processor.Find(tx, id)
+		return err
+	})
+
+	s.logResult(ctx, "found", id, err)
+	return result, err
+}
+
+func (s *fileStorage[ID, T]) Insert(ctx context.Context, id ID, value T) (T, error) {
+	s.logger.Debug(ctx, "Inserting new record", service.Fields{"id": id})
+	err := s.store.Update(func(tx *Tx) error {
+		return s.processor.Insert(tx, id, value)
+	})
+
+	s.logResult(ctx, "inserted", id, err)
+	return value, err
+}
+
+func (s *fileStorage[ID, T]) Update(ctx context.Context, id ID, value T) (T, error) {
+	s.logger.Debug(ctx, "Updating record", service.Fields{"id": id})
+	err := s.store.Update(func(tx *Tx) error {
+		return s.processor.Update(tx, id, value)
+	})
+
+	s.logResult(ctx, "updated", id, err)
+	return value, err
+}
+
+func (s *fileStorage[ID, T]) Delete(ctx context.Context, id ID) error {
+	s.logger.Debug(ctx, "Deleting record", service.Fields{"id": id})
+	err := s.store.Update(func(tx *Tx) error {
+		return s.processor.Delete(tx, id)
+	})
+
+	s.logResult(ctx, "deleted", id, err)
+	return err
+}
@@ -0,0 +1,168 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package file
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Row is a stored value together with the time it was last written.
+type Row struct {
+	Value     json.RawMessage `json:"value"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// Decode unmarshals the stored value into v.
+func (r Row) Decode(v any) error {
+	return json.Unmarshal(r.Value, v)
+}
+
+type table map[string]Row
+
+// Store keeps every table in memory and persists them to a single JSON file
+// after each write. It is meant for single-instance deployments: the file
+// must not be shared between processes.
+type Store struct {
+	path string
+
+	mu     sync.RWMutex
+	tables map[string]table
+}
+
+// NewFileStore opens the store at path, creating it on the first write.
+func NewFileStore(path string) (*Store, error) {
+	store := &Store{
+		path:   path,
+		tables: make(map[string]table),
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file store: %w", err)
+	}
+
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &store.tables); err != nil {
+			return nil, fmt.Errorf("failed to parse file store: %w", err)
+		}
+	}
+
+	return store, nil
+}
+
+// View runs fn with a read-only transaction.
+func (s *Store) View(fn func(tx *Tx) error) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return fn(newTx(s.tables))
+}
+
+// Update runs fn with a read-write transaction. Writes are applied and
+// persisted only when fn succeeds.
+func (s *Store) Update(fn func(tx *Tx) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tx := newTx(s.tables)
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	if len(tx.writes) == 0 {
+		return nil
+	}
+
+	tables := make(map[string]table, len(s.tables)+len(tx.writes))
+	for name, rows := range s.tables {
+		tables[name] = rows
+	}
+
+	for name, writes := range tx.writes {
+		rows := make(table, len(tables[name])+len(writes))
+		for key, row := range tables[name] {
+			rows[key] = row
+		}
+
+		for key, row := range writes {
+			if row == nil {
+				delete(rows, key)
+			} else {
+				rows[key] = *row
+			}
+		}
+
+		tables[name] = rows
+	}
+
+	if err := s.persist(tables); err != nil {
+		return err
+	}
+
+	s.tables = tables
+	return nil
+}
+
+// persist atomically replaces the store file with tables.
+func (s *Store) persist(tables map[string]table) error {
+	data, err := json.Marshal(tables)
+	if err != nil {
+		return fmt.Errorf("failed to encode file store: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create file store directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create file store: %w", err)
+	}
+
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write file store: %w", err)
+	}
+
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to sync file store: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close file store: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to replace file store: %w", err)
+	}
+
+	return nil
+}
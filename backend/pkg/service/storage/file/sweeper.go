@@ -0,0 +1,73 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package file
+
+import (
+	"context"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/service"
+)
+
+type expirationSweeper struct {
+	store  *Store
+	table  string
+	logger service.Logger
+}
+
+// NewExpirationSweeper deletes rows of table that were last written before
+// the sweep cutoff.
+func NewExpirationSweeper(store *Store, table string, logger service.Logger) (service.Sweeper, error) {
+	if store == nil {
+		return nil, ErrNilStore
+	}
+
+	return &expirationSweeper{
+		store:  store,
+		table:  table,
+		logger: logger,
+	}, nil
+}
+
+func (s *expirationSweeper) Sweep(ctx context.Context, before time.Time) (int64, error) {
+	var swept int64
+	err := s.store.Update(func(tx *Tx) error {
+		for _, key := range tx.Keys(s.table) {
+			if row, ok := tx.Get(s.table, key); ok && row.UpdatedAt.Before(before) {
+				tx.Delete(s.table, key)
+				swept++
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		s.logger.Error(ctx, "Error sweeping expired records", service.Fields{
+			"table": s.table,
+			"error": err.Error(),
+		})
+		return 0, err
+	}
+
+	s.logger.Debug(ctx, "Expired records swept", service.Fields{
+		"table":         s.table,
+		"rows_affected": swept,
+	})
+	return swept, nil
+}
@@ -0,0 +1,119 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package file
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+)
+
+// Tx reads committed rows and buffers writes until its Update completes.
+type Tx struct {
+	tables map[string]table
+	writes map[string]map[string]*Row
+}
+
+func newTx(tables map[string]table) *Tx {
+	return &Tx{
+		tables: tables,
+		writes: make(map[string]map[string]*Row),
+	}
+}
+
+// Key builds a record key from the parts of a composite key.
+func Key(parts ...string) string {
+	key, _ := json.Marshal(parts)
+	return string(key)
+}
+
+// SplitKey returns the parts of a key built with Key.
+func SplitKey(key string) ([]string, error) {
+	var parts []string
+	if err := json.Unmarshal([]byte(key), &parts); err != nil {
+		return nil, ErrInvalidKey
+	}
+
+	return parts, nil
+}
+
+// Get returns the row stored under key, including uncommitted writes.
+func (tx *Tx) Get(name, key string) (Row, bool) {
+	if row, ok := tx.writes[name][key]; ok {
+		if row == nil {
+			return Row{}, false
+		}
+
+		return *row, true
+	}
+
+	row, ok := tx.tables[name][key]
+	return row, ok
+}
+
+// Put stores value under key and stamps the row with the current time.
+func (tx *Tx) Put(name, key string, value any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	if tx.writes[name] == nil {
+		tx.writes[name] = make(map[string]*Row)
+	}
+
+	tx.writes[name][key] = &Row{
+		Value:     data,
+		UpdatedAt: time.Now(),
+	}
+
+	return nil
+}
+
+// Delete removes the row stored under key and reports whether it existed.
+func (tx *Tx) Delete(name, key string) bool {
+	if _, ok := tx.Get(name, key); !ok {
+		return false
+	}
+
+	if tx.writes[name] == nil {
+		tx.writes[name] = make(map[string]*Row)
+	}
+
+	tx.writes[name][key] = nil
+	return true
+}
+
+// Keys returns the sorted keys of every row in a table.
+func (tx *Tx) Keys(name string) []string {
+	keys := make([]string, 0, len(tx.tables[name])+len(tx.writes[name]))
+	for key := range tx.tables[name] {
+		if _, ok := tx.writes[name][key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+
+	for key, row := range tx.writes[name] {
+		if row != nil {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+	return keys
+}
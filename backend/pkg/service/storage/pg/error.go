@@ -17,9 +17,13 @@
  */
 package pg
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/service"
+)
 
 var (
 	ErrNilPool        = errors.New("received a nil pgx pool")
-	ErrNoRowsAffected = errors.New("no rows have been affected")
+	ErrNoRowsAffected = service.ErrNoRowsAffected
 )
@@ -0,0 +1,91 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package pg
+
+import (
+	"context"
+
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/service"
+	pgx "github.com/jackc/pgx/v5"
+	pgxpool "github.com/jackc/pgx/v5/pgxpool"
+)
+
+type postgresLister[K comparable, V any, F any] struct {
+	pool      *pgxpool.Pool
+	processor service.ListProcessor[K, V, F, pgx.Rows]
+	logger    service.Logger
+}
+
+func NewPostgresLister[K comparable, V any, F any](
+	pool *pgxpool.Pool,
+	processor service.ListProcessor[K, V, F, pgx.Rows],
+	logger service.Logger,
+) (service.Lister[K, V, F], error) {
+	if pool == nil {
+		return nil, ErrNilPool
+	}
+
+	return &postgresLister[K, V, F]{
+		pool:      pool,
+		processor: processor,
+		logger:    logger,
+	}, nil
+}
+
+func (l *postgresLister[K, V, F]) List(ctx context.Context, filter F) ([]service.Record[K, V], error) {
+	query, args, scanner := l.processor.BuildListQuery(filter)
+
+	rows, err := l.pool.Query(ctx, query, args...)
+	if err != nil {
+		l.logger.Error(ctx, "Error listing records", service.Fields{
+			"query": query,
+			"error": err.Error(),
+		})
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var records []service.Record[K, V]
+	for rows.Next() {
+		key, value, err := scanner(rows)
+		if err != nil {
+			l.logger.Error(ctx, "Error scanning listed record", service.Fields{
+				"query": query,
+				"error": err.Error(),
+			})
+			return nil, err
+		}
+
+		records = append(records, service.Record[K, V]{Key: key, Value: value})
+	}
+
+	if err := rows.Err(); err != nil {
+		l.logger.Error(ctx, "Error iterating listed records", service.Fields{
+			"query": query,
+			"error": err.Error(),
+		})
+		return nil, err
+	}
+
+	l.logger.Debug(ctx, "Records listed successfully", service.Fields{
+		"query": query,
+		"count": len(records),
+	})
+	return records, nil
+}
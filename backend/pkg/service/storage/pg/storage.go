@@ -25,8 +25,12 @@ import (
 	"time"
 
 	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/service"
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/tracing"
 	pgx "github.com/jackc/pgx/v5"
 	pgxpool "github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func generateStatementName(query string) string {
@@ -34,6 +38,26 @@ func generateStatementName(query string) string {
 	return fmt.Sprintf("stmt_%x", h)
 }
 
+var tracer = otel.Tracer("github.com/ONLYOFFICE/onlyoffice-miro/backend/pkg/service/storage/pg")
+
+// startSpan starts a client span for a single statement run by the storage.
+func startSpan(ctx context.Context, operation string) (context.Context, trace.Span) {
+	return tracer.Start(ctx, "postgres."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.DBSystemPostgreSQL, semconv.DBOperationName(operation)),
+	)
+}
+
+// endSpan ends a storage span. A missing record is an expected outcome, not
+// an error of the span.
+func endSpan(span trace.Span, err error) {
+	if errors.Is(err, ErrNoRowsAffected) {
+		err = nil
+	}
+
+	tracing.End(span, err)
+}
+
 type postgresStorage[ID comparable, T any] struct {
 	pool      *pgxpool.Pool
 	processor service.StorageProcessor[ID, T, pgx.Row]
@@ -67,6 +91,8 @@ func NewPostgresStorage[ID comparable, T any](
 }
 
 func (s *postgresStorage[ID, T]) Find(ctx context.Context, id ID) (T, error) {
+	ctx, span := startSpan(ctx, "select")
+
 	var result T
 
 	s.logger.Debug(ctx, "Finding record by ID", service.Fields{"id": id})
@@ -106,10 +132,13 @@ func (s *postgresStorage[ID, T]) Find(ctx context.Context, id ID) (T, error) {
 		})
 	}
 
+	endSpan(span, err)
 	return result, err
 }
 
 func (s *postgresStorage[ID, T]) Insert(ctx context.Context, id ID, value T) (T, error) {
+	ctx, span := startSpan(ctx, "insert")
+
 	s.logger.Debug(ctx, "Inserting new record", service.Fields{"id": id})
 
 	query, args := s.processor.BuildInsertQuery(id, value)
@@ -133,10 +162,13 @@ func (s *postgresStorage[ID, T]) Insert(ctx context.Context, id ID, value T) (T,
 		return nil
 	})
 
+	endSpan(span, err)
 	return value, err
 }
 
 func (s *postgresStorage[ID, T]) Update(ctx context.Context, id ID, value T) (T, error) {
+	ctx, span := startSpan(ctx, "update")
+
 	s.logger.Debug(ctx, "Updating record", service.Fields{"id": id})
 
 	query, args := s.processor.BuildUpdateQuery(id, value)
@@ -175,10 +207,13 @@ func (s *postgresStorage[ID, T]) Update(ctx context.Context, id ID, value T) (T,
 		})
 	}
 
+	endSpan(span, err)
 	return value, err
 }
 
 func (s *postgresStorage[ID, T]) Delete(ctx context.Context, id ID) error {
+	ctx, span := startSpan(ctx, "delete")
+
 	s.logger.Debug(ctx, "Deleting record", service.Fields{"id": id})
 
 	query, args := s.processor.BuildDeleteQuery(id)
@@ -217,5 +252,6 @@ func (s *postgresStorage[ID, T]) Delete(ctx context.Context, id ID) error {
 		})
 	}
 
+	endSpan(span, err)
 	return err
 }
@@ -0,0 +1,66 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2025
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package pg
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-miro/backend/internal/pkg/service"
+	pgx "github.com/jackc/pgx/v5"
+	pgxpool "github.com/jackc/pgx/v5/pgxpool"
+)
+
+type expirationSweeper struct {
+	pool   *pgxpool.Pool
+	query  string
+	logger service.Logger
+}
+
+// NewExpirationSweeper deletes rows of table whose column holds a timestamp
+// older than the sweep cutoff.
+func NewExpirationSweeper(pool *pgxpool.Pool, table, column string, logger service.Logger) (service.Sweeper, error) {
+	if pool == nil {
+		return nil, ErrNilPool
+	}
+
+	return &expirationSweeper{
+		pool: pool,
+		query: fmt.Sprintf("DELETE FROM %s WHERE %s < $1;",
+			pgx.Identifier{table}.Sanitize(), pgx.Identifier{column}.Sanitize()),
+		logger: logger,
+	}, nil
+}
+
+func (s *expirationSweeper) Sweep(ctx context.Context, before time.Time) (int64, error) {
+	res, err := s.pool.Exec(ctx, s.query, before)
+	if err != nil {
+		s.logger.Error(ctx, "Error sweeping expired records", service.Fields{
+			"query": s.query,
+			"error": err.Error(),
+		})
+		return 0, err
+	}
+
+	s.logger.Debug(ctx, "Expired records swept", service.Fields{
+		"query":         s.query,
+		"rows_affected": res.RowsAffected(),
+	})
+	return res.RowsAffected(), nil
+}